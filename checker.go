@@ -0,0 +1,124 @@
+package gspell
+
+// #include "checker.h"
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/diamondburned/gspell/gextras"
+	"github.com/gotk3/gotk3/glib"
+)
+
+// packWords packs the words into a single buffer. Word i spans from offsets[i]
+// to offsets[i+1]. The buffer is never empty, so that it can be given to C.
+func packWords(words []string) (buf []byte, offsets []C.gsize) {
+	var size int
+	for _, word := range words {
+		size += len(word)
+	}
+
+	buf = make([]byte, 0, size+1)
+	offsets = make([]C.gsize, len(words)+1)
+
+	for i, word := range words {
+		buf = append(buf, word...)
+		offsets[i+1] = C.gsize(len(buf))
+	}
+
+	return append(buf, 0), offsets
+}
+
+// CheckWords checks the spelling of all words with a single cgo call. It's
+// faster than calling CheckWord for each word. The result of each word is true
+// if it's correctly spelled.
+func (c *Checker) CheckWords(words []string) ([]bool, error) {
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	buf, offsets := packWords(words)
+	cresults := make([]C.gboolean, len(words))
+
+	var failed C.gsize
+	var gerr *C.GError
+
+	ok := C.gspell_go_check_words(
+		c.native(),
+		(*C.gchar)(unsafe.Pointer(&buf[0])), &offsets[0], C.gsize(len(words)),
+		&cresults[0], &failed, &gerr,
+	)
+
+	if !gextras.Gobool(int(ok)) {
+		err := gextras.TakeError(unsafe.Pointer(gerr))
+		return nil, fmt.Errorf("failed to check word %q: %w", words[failed], err)
+	}
+
+	results := make([]bool, len(words))
+	for i, r := range cresults {
+		results[i] = gextras.Gobool(int(r))
+	}
+
+	return results, nil
+}
+
+// GetSuggestionsBatch gets the suggestions of all words with a single cgo call.
+// The suggestions of words[i] are in the returned slice at index i.
+func (c *Checker) GetSuggestionsBatch(words []string) [][]string {
+	if len(words) == 0 {
+		return nil
+	}
+
+	buf, offsets := packWords(words)
+	counts := make([]C.gsize, len(words))
+
+	var length C.gsize
+
+	out := C.gspell_go_get_suggestions(
+		c.native(),
+		(*C.gchar)(unsafe.Pointer(&buf[0])), &offsets[0], C.gsize(len(words)),
+		&counts[0], &length,
+	)
+	defer C.g_free(C.gpointer(unsafe.Pointer(out)))
+
+	all := C.GoStringN(out, C.int(length))
+	suggestions := make([][]string, len(words))
+
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		suggestions[i] = make([]string, count)
+
+		for j := range suggestions[i] {
+			end := 0
+			for all[end] != 0 {
+				end++
+			}
+
+			suggestions[i][j] = all[:end]
+			all = all[end+1:]
+		}
+	}
+
+	return suggestions
+}
+
+// takeStrings converts a list of strings returned by GetSuggestions, then frees
+// it with its strings.
+func takeStrings(list *glib.SList) []string {
+	if list == nil {
+		return nil
+	}
+
+	strs := make([]string, 0, list.Length())
+	for l := list; l != nil; l = l.Next() {
+		strs = append(strs, C.GoString((*C.gchar)(l.DataRaw())))
+		C.g_free(C.gpointer(l.DataRaw()))
+	}
+
+	list.Free()
+	return strs
+}
@@ -0,0 +1,48 @@
+#include <string.h>
+#include <gspell/gspell.h>
+
+// gspell_go_check_words checks the n words packed in buf, where word i spans
+// from offsets[i] to offsets[i+1], and writes the results into results. If
+// checking a word fails, then its index is written into failed and FALSE is
+// returned.
+static gboolean gspell_go_check_words(GspellChecker *checker, const gchar *buf,
+		const gsize *offsets, gsize n, gboolean *results, gsize *failed,
+		GError **error) {
+	for (gsize i = 0; i < n; i++) {
+		results[i] = gspell_checker_check_word(checker, buf + offsets[i],
+				offsets[i + 1] - offsets[i], error);
+
+		if (*error != NULL) {
+			*failed = i;
+			return FALSE;
+		}
+	}
+
+	return TRUE;
+}
+
+// gspell_go_get_suggestions gets the suggestions of the n words packed in buf
+// like gspell_go_check_words. The suggestions are returned nul-terminated in a
+// single buffer of length len that must be freed with g_free. The number of
+// suggestions of each word is written into counts.
+static gchar *gspell_go_get_suggestions(GspellChecker *checker, const gchar *buf,
+		const gsize *offsets, gsize n, gsize *counts, gsize *len) {
+	GString *out = g_string_new(NULL);
+
+	for (gsize i = 0; i < n; i++) {
+		GSList *suggestions = gspell_checker_get_suggestions(checker,
+				buf + offsets[i], offsets[i + 1] - offsets[i]);
+
+		counts[i] = 0;
+
+		for (GSList *l = suggestions; l != NULL; l = l->next) {
+			g_string_append_len(out, l->data, strlen(l->data) + 1);
+			counts[i]++;
+		}
+
+		g_slist_free_full(suggestions, g_free);
+	}
+
+	*len = out->len;
+	return g_string_free(out, FALSE);
+}
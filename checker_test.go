@@ -0,0 +1,169 @@
+package gspell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var benchText = strings.Fields(`
+	The quick brown fox jumps over the lazy dog while the speling checker
+	looks for misteaks in every singel word of this sentense, which is
+	repeated until the document has ten thousand words in it.
+`)
+
+// benchWords returns n words taken from benchText.
+func benchWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = benchText[i%len(benchText)]
+	}
+	return words
+}
+
+func benchChecker(b *testing.B) *Checker {
+	lang := LanguageGetDefault()
+	if lang == nil {
+		b.Skip("no dictionary installed")
+	}
+	return CheckerNew(lang)
+}
+
+// testChecker returns a Checker for American English, which the expectations
+// of the tests are written for.
+func testChecker(t *testing.T) *Checker {
+	lang := LanguageLookup("en_US")
+	if lang == nil {
+		t.Skip("no en_US dictionary installed")
+	}
+	return CheckerNew(lang)
+}
+
+// testWords are words that the batch functions are compared on: empty,
+// correctly spelled, misspelled, multi-byte and without any suggestion.
+var testWords = []string{
+	"",
+	"hello",
+	"wrold",
+	"naïve",
+	"naïv",
+	"Größe",
+	"日本語",
+	"zzxqzzxqzzxq",
+	"123",
+}
+
+func TestCheckWords(t *testing.T) {
+	checker := testChecker(t)
+
+	if results, err := checker.CheckWords(nil); results != nil || err != nil {
+		t.Errorf("CheckWords(nil) = %v, %v; want nil, nil", results, err)
+	}
+
+	var words []string
+	var want []bool
+
+	for _, word := range testWords {
+		correct := checker.CheckWord(word, len(word))
+
+		results, err := checker.CheckWords([]string{word})
+		if err != nil {
+			// CheckWord returns false on errors.
+			if correct {
+				t.Errorf("CheckWords(%q) failed but CheckWord accepts it: %v", word, err)
+			}
+			continue
+		}
+
+		if results[0] != correct {
+			t.Errorf("CheckWords(%q) = %v, CheckWord = %v", word, results[0], correct)
+		}
+
+		words = append(words, word)
+		want = append(want, correct)
+	}
+
+	got, err := checker.CheckWords(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckWords(%q) = %v, want %v", words, got, want)
+	}
+}
+
+func TestGetSuggestionsBatch(t *testing.T) {
+	checker := testChecker(t)
+
+	if got := checker.GetSuggestionsBatch(nil); got != nil {
+		t.Errorf("GetSuggestionsBatch(nil) = %q, want nil", got)
+	}
+
+	got := checker.GetSuggestionsBatch(testWords)
+	if len(got) != len(testWords) {
+		t.Fatalf("got %d suggestion lists for %d words", len(got), len(testWords))
+	}
+
+	var none bool
+
+	for i, word := range testWords {
+		want := takeStrings(checker.GetSuggestions(word, len(word)))
+		if len(want) == 0 {
+			none = true
+		}
+
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("suggestions of %q: got %q, want %q", word, got[i], want)
+		}
+	}
+
+	if !none {
+		t.Error("no word without suggestions was tested")
+	}
+}
+
+func BenchmarkCheckWord(b *testing.B) {
+	checker := benchChecker(b)
+	words := benchWords(10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			checker.CheckWord(word, len(word))
+		}
+	}
+}
+
+func BenchmarkCheckWords(b *testing.B) {
+	checker := benchChecker(b)
+	words := benchWords(10000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := checker.CheckWords(words); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetSuggestions(b *testing.B) {
+	checker := benchChecker(b)
+	words := benchWords(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			takeStrings(checker.GetSuggestions(word, len(word)))
+		}
+	}
+}
+
+func BenchmarkGetSuggestionsBatch(b *testing.B) {
+	checker := benchChecker(b)
+	words := benchWords(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		checker.GetSuggestionsBatch(words)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/diamondburned/gspell/internal/gir"
+)
+
+// apiDiff prints the API changes between two gir files.
+func apiDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "output format, either text or json")
+	namespace := fs.String("namespace", "", "name of the namespace to compare")
+	failBreaking := fs.Bool("fail-breaking", false, "exit with status 1 if there are breaking changes")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: girgen diff [flags] old.gir new.gir")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	// Only one repository can be parsed at a time, so dump each before
+	// parsing the next one.
+	var dumps [2]gir.Dump
+	for i, girPath := range fs.Args() {
+		nsIndex, err := parseNamespace(girPath, *namespace, "")
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		dumps[i] = gir.SetActiveNamespace(nsIndex).Dump()
+	}
+
+	d := gir.DiffDumps(dumps[0], dumps[1])
+
+	var err error
+
+	switch *format {
+	case "text":
+		err = d.WriteText(os.Stdout)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(d)
+	default:
+		log.Fatalln("Unknown format:", *format)
+	}
+
+	if err != nil {
+		log.Fatalln("Failed to write diff:", err)
+	}
+
+	if *failBreaking && d.Breaking > 0 {
+		os.Exit(1)
+	}
+}
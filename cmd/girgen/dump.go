@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/diamondburned/gspell/internal/gir"
+	"github.com/pkg/errors"
+)
+
+// dump writes the resolved model of a namespace as JSON.
+func dump(args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	output := fs.String("o", "", "file to write the JSON to; defaults to stdout")
+	namespace := fs.String("namespace", "", "name of the namespace to dump")
+	version := fs.String("version", "", "version of the namespace to dump")
+	codeOutput := fs.String("code", "",
+		"output file or directory of the generated code, used to find hand-written files")
+	pkgName := fs.String("package", "", "Go package name of the generated code")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: girgen dump [flags] file.gir")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args)
+
+	var girPath = fs.Arg(0)
+	if girPath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	t := target{
+		GIR:       girPath,
+		Namespace: *namespace,
+		Version:   *version,
+		Package:   *pkgName,
+		Output:    *codeOutput,
+	}
+
+	if *output == "" {
+		return writeDump(os.Stdout, t)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file")
+	}
+
+	if err := writeDump(f, t); err != nil {
+		f.Close()
+		return err
+	}
+
+	return errors.Wrap(f.Close(), "Failed to write dump")
+}
+
+// writeDump writes the dump of the target's namespace. Symbols hand-written in
+// the target package are marked as manual, like in the coverage report.
+func writeDump(w io.Writer, t target) error {
+	if _, _, err := t.load(); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+
+	if err := enc.Encode(gir.ActiveNamespace().Dump()); err != nil {
+		return errors.Wrap(err, "Failed to write dump")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestWriteDump(t *testing.T) {
+	const golden = "testdata/Sample-1.0.dump.json"
+
+	var buf bytes.Buffer
+	err := writeDump(&buf, target{GIR: "testdata/Sample-1.0.gir", Output: "testdata/manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("the dump differs from %s:\n%s", golden,
+			unifiedDiff(golden, "dump", want, buf.Bytes()))
+	}
+}
+
+func TestWriteDumpMissingGIR(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDump(&buf, target{GIR: "testdata/Missing-1.0.gir"}); err == nil {
+		t.Error("a missing gir file was dumped")
+	}
+}
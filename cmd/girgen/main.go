@@ -1,41 +1,252 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/diamondburned/gspell/internal/gir"
 )
 
+var (
+	output       string
+	pkgName      string
+	runtimePath  string
+	namespace    string
+	version      string
+	verify       bool
+	pkgConfig    string
+	keepGoing    bool
+	manifestPath string
+)
+
+func init() {
+	flag.StringVar(&output, "o", "",
+		"output file or directory, defaults to ./<namespace>_generated.go")
+	flag.StringVar(&pkgName, "package", "",
+		"Go package name, defaults to the lower-cased namespace name")
+	flag.StringVar(&runtimePath, "runtime", gir.RuntimePath,
+		"import path of the runtime support package imported by generated code")
+	flag.StringVar(&namespace, "namespace", "",
+		"name of the namespace to generate, defaults to the first one")
+	flag.StringVar(&version, "version", "",
+		"version of the namespace to generate")
+	flag.StringVar(&pkgConfig, "pkg", "",
+		"pkg-config package to find the gir file of, used if no file is given")
+	flag.BoolVar(&keepGoing, "keep-going", false,
+		"skip symbols that fail to generate instead of stopping at the first one")
+	flag.StringVar(&manifestPath, "manifest", "",
+		"JSON manifest listing the namespaces to generate, used instead of a file")
+	flag.BoolVar(&verify, "verify", false,
+		"compare the generated files with the ones on disk instead of writing them")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: girgen [flags] file.gir")
+		fmt.Fprintln(flag.CommandLine.Output(), "       girgen [flags] -pkg package")
+		fmt.Fprintln(flag.CommandLine.Output(), "       girgen [flags] -manifest girgen.json")
+		fmt.Fprintln(flag.CommandLine.Output(), "       girgen report [flags] file.gir")
+		fmt.Fprintln(flag.CommandLine.Output(), "       girgen dump [flags] file.gir")
+		fmt.Fprintln(flag.CommandLine.Output(), "       girgen diff [flags] old.gir new.gir")
+		fmt.Fprintln(flag.CommandLine.Output(), "Use - as the file to read from stdin.")
+		flag.PrintDefaults()
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "report":
+			report(os.Args[2:])
+			return
+		case "dump":
+			if err := dump(os.Args[2:]); err != nil {
+				log.Fatalln(err)
+			}
+			return
+		case "diff":
+			apiDiff(os.Args[2:])
+			return
+		}
+	}
+
 	flag.Parse()
 
-	var girPath = flag.Arg(0)
-	if girPath == "" {
-		log.Fatalln("Missing .gir path. Usage: girgen file.gir.")
+	gir.KeepGoing = keepGoing
+
+	var targets []target
+
+	if manifestPath != "" {
+		m, err := readManifest(manifestPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		gir.RuntimePath = m.Runtime
+		targets = m.Packages
+
+		for _, t := range targets {
+			if err := t.addExternal(); err != nil {
+				log.Fatalln(err)
+			}
+		}
+	} else {
+		var girPath = flag.Arg(0)
+		if girPath == "" && pkgConfig != "" {
+			p, err := findPkgGir(pkgConfig)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			girPath = p
+		}
+
+		if girPath == "" {
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		gir.RuntimePath = runtimePath
+		targets = []target{{
+			GIR:       girPath,
+			Namespace: namespace,
+			Version:   version,
+			Package:   pkgName,
+			Output:    output,
+		}}
+	}
+
+	var files = map[string][]byte{}
+	var genErr error
+
+	for _, t := range targets {
+		if genErr = t.generate(files); genErr != nil {
+			break
+		}
+	}
+
+	printDiagnostics()
+	if genErr != nil {
+		log.Fatalln(genErr)
 	}
 
-	if err := gir.ParseRepositoryFile(girPath); err != nil {
-		log.Fatalln(err)
+	if verify {
+		if !verifyFiles(files) {
+			os.Exit(1)
+		}
+	} else {
+		for _, path := range sortedPaths(files) {
+			if err := ioutil.WriteFile(path, files[path], 0644); err != nil {
+				log.Fatalln("Failed to write output file:", err)
+			}
+		}
 	}
 
-	gen := gir.NewGotk3Generator("gspell")
-	gir.SetActiveNamespace(0).GenerateToFile(gen)
+	// The output is only a best-effort one if symbols were skipped.
+	if gir.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+// printDiagnostics prints the diagnostics reported by the generator followed by
+// a summary, if there are any.
+func printDiagnostics() {
+	var diagnostics = gir.Diagnostics()
+	if len(diagnostics) == 0 {
+		return
+	}
+
+	var errors, warnings int
 
-	// Process the filename.
-	outputPath := strings.Split(girPath, ".")[0]
-	outputPath = strings.ToLower(outputPath)
-	// Optionally trim the version dash.
-	outputPath = strings.Split(outputPath, "-")[0]
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, d)
+
+		switch d.Severity {
+		case gir.SeverityError:
+			errors++
+		case gir.SeverityWarning:
+			warnings++
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d error(s), %d warning(s)\n", errors, warnings)
+}
+
+// verifyFiles compares the generated files with the ones on disk and prints a
+// unified diff for each file that differs. It returns true if all files are up
+// to date.
+func verifyFiles(files map[string][]byte) bool {
+	var upToDate = true
+
+	for _, path := range sortedPaths(files) {
+		onDisk, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			log.Fatalln("Failed to read file:", err)
+		}
+
+		if bytes.Equal(onDisk, files[path]) {
+			continue
+		}
+
+		upToDate = false
+		fmt.Fprintf(os.Stderr, "%s is out of date\n", path)
+		fmt.Print(unifiedDiff(path, path+" (generated)", onDisk, files[path]))
+	}
+
+	return upToDate
+}
+
+func sortedPaths(files map[string][]byte) []string {
+	var paths = make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// parseNamespace parses the gir file and returns the index of the namespace
+// with the given name and version. The path "-" reads from stdin.
+func parseNamespace(girPath, name, version string) (int, error) {
+	var err error
+	if girPath == "-" {
+		err = gir.ParseRepository(os.Stdin)
+	} else {
+		err = gir.ParseRepositoryFile(girPath)
+	}
 
-	f, err := os.Create(fmt.Sprintf("%s_generated.go", outputPath))
 	if err != nil {
-		log.Fatalln("Failed to create output file:", err)
+		return 0, err
+	}
+
+	return gir.FindNamespace(name, version)
+}
+
+// outputPath resolves the output flag into a file path. If the flag points to a
+// directory, then the file is named after the package inside that directory. A
+// trailing separator marks a directory. The directory must exist.
+func outputPath(output, pkgName string) (string, error) {
+	var fileName = pkgName + "_generated.go"
+
+	if output == "" {
+		return fileName, nil
+	}
+
+	var path = output
+	if strings.HasSuffix(output, string(filepath.Separator)) {
+		path = filepath.Join(output, fileName)
+	} else if s, err := os.Stat(output); err == nil && s.IsDir() {
+		path = filepath.Join(output, fileName)
+	}
+
+	var dir = filepath.Dir(path)
+	if s, err := os.Stat(dir); err != nil || !s.IsDir() {
+		return "", fmt.Errorf("output directory %s does not exist", dir)
 	}
-	defer f.Close()
 
-	fmt.Fprintf(f, "%#v", gen)
+	return path, nil
 }
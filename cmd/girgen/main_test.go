@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestOutputPath(t *testing.T) {
+	dir := t.TempDir()
+	sep := string(filepath.Separator)
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+		err    bool
+	}{{
+		name: "default",
+		want: "sample_generated.go",
+	}, {
+		name:   "directory",
+		output: dir,
+		want:   filepath.Join(dir, "sample_generated.go"),
+	}, {
+		name:   "directory with trailing separator",
+		output: dir + sep,
+		want:   filepath.Join(dir, "sample_generated.go"),
+	}, {
+		name:   "file",
+		output: filepath.Join(dir, "bindings.go"),
+		want:   filepath.Join(dir, "bindings.go"),
+	}, {
+		name:   "file in a missing directory",
+		output: filepath.Join(dir, "missing", "bindings.go"),
+		err:    true,
+	}, {
+		name:   "missing directory with trailing separator",
+		output: filepath.Join(dir, "missing") + sep,
+		err:    true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := outputPath(test.output, "sample")
+			if test.err {
+				if err == nil {
+					t.Errorf("got %q, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTargetGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		target target
+		want   []string
+		err    string
+	}{{
+		name:   "first namespace",
+		target: target{GIR: "testdata/Sample-1.0.gir", Output: dir},
+		want:   []string{"sample_generated.c", "sample_generated.go", "sample_generated.h"},
+	}, {
+		name:   "namespace by name and version",
+		target: target{GIR: "testdata/Sample-1.0.gir", Namespace: "Sample", Version: "1", Package: "smp", Output: dir},
+		want:   []string{"smp_generated.c", "smp_generated.go", "smp_generated.h"},
+	}, {
+		name:   "unknown namespace",
+		target: target{GIR: "testdata/Sample-1.0.gir", Namespace: "Gtk", Output: dir},
+		err:    `no namespace matching name "Gtk"`,
+	}, {
+		name:   "unknown version",
+		target: target{GIR: "testdata/Sample-1.0.gir", Version: "2", Output: dir},
+		err:    `version "2"`,
+	}, {
+		name:   "missing gir file",
+		target: target{GIR: "testdata/Missing-1.0.gir", Output: dir},
+		err:    "Missing-1.0.gir",
+	}, {
+		name:   "missing output directory",
+		target: target{GIR: "testdata/Sample-1.0.gir", Output: filepath.Join(dir, "missing", "out.go")},
+		err:    "does not exist",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := map[string][]byte{}
+
+			err := test.target.generate(files)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("got error %v, want one containing %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for path := range files {
+				if filepath.Dir(path) != dir {
+					t.Errorf("%s is outside of the output directory", path)
+				}
+				got = append(got, filepath.Base(path))
+			}
+			sort.Strings(got)
+
+			if strings.Join(got, " ") != strings.Join(test.want, " ") {
+				t.Errorf("got files %q, want %q", got, test.want)
+			}
+
+			// Nothing is written until the files are saved.
+			if _, err := os.Stat(filepath.Join(dir, test.want[0])); !os.IsNotExist(err) {
+				t.Errorf("%s was written", test.want[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/diamondburned/gspell/internal/gir"
+	"github.com/pkg/errors"
+)
+
+// manifest lists the namespaces generated by a single girgen run. Namespaces
+// listed in the same manifest can reference each other's types.
+type manifest struct {
+	// Runtime is the import path of the runtime support package, which
+	// defaults to gextras.
+	Runtime  string   `json:"runtime,omitempty"`
+	Packages []target `json:"packages"`
+}
+
+// target is a single namespace to generate into a Go package.
+type target struct {
+	// GIR is the path to the gir file, or - for stdin outside of manifests.
+	// Paths in a manifest are relative to the manifest.
+	GIR        string `json:"gir"`
+	Namespace  string `json:"namespace,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Package    string `json:"package,omitempty"`
+	ImportPath string `json:"import_path"`
+	Output     string `json:"output,omitempty"`
+}
+
+// readManifest reads the manifest at the given path.
+func readManifest(path string) (manifest, error) {
+	var m = manifest{Runtime: gir.RuntimePath}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return m, errors.Wrap(err, "Failed to open manifest")
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+
+	if err := d.Decode(&m); err != nil {
+		return m, errors.Wrap(err, "Failed to decode manifest")
+	}
+
+	var dir = filepath.Dir(path)
+
+	for i, t := range m.Packages {
+		if t.GIR == "" || t.ImportPath == "" {
+			return m, fmt.Errorf("package %d in manifest needs both gir and import_path", i)
+		}
+
+		// Every gir file is parsed twice, once to register it as external and
+		// once to generate it, so stdin can't be read.
+		if t.GIR == "-" {
+			return m, fmt.Errorf("package %d in manifest can't read its gir from stdin", i)
+		}
+
+		m.Packages[i].GIR = relativeTo(dir, t.GIR)
+		if t.Output != "" {
+			// Keep the trailing slash, which marks a directory.
+			out := relativeTo(dir, t.Output)
+			if strings.HasSuffix(t.Output, "/") {
+				out += string(filepath.Separator)
+			}
+			m.Packages[i].Output = out
+		}
+	}
+
+	return m, nil
+}
+
+func relativeTo(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// addExternal registers the namespace of the target, so that the other targets
+// reference its types through its package.
+func (t target) addExternal() error {
+	nsIndex, err := parseNamespace(t.GIR, t.Namespace, t.Version)
+	if err != nil {
+		return err
+	}
+
+	gir.SetActiveNamespace(nsIndex).AddExternal(t.ImportPath)
+	return nil
+}
+
+// generate generates the target and adds the generated files to the given map.
+// gir.ErrAborted is returned if a symbol failed to generate.
+func (t target) generate(files map[string][]byte) error {
+	pkgName, goPath, err := t.load()
+	if err != nil {
+		return err
+	}
+
+	ns := gir.ActiveNamespace()
+
+	var basePath = strings.TrimSuffix(goPath, ".go")
+	var headerName = filepath.Base(basePath) + ".h"
+
+	gen := gir.NewGotk3Generator(pkgName)
+	if err := ns.GenerateToFile(gen, headerName); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := gen.Render(&buf); err != nil {
+		return errors.Wrap(err, "Failed to render generated code")
+	}
+
+	files[goPath] = buf.Bytes()
+	files[basePath+".h"] = ns.GenerateCHeader(headerName)
+	files[basePath+".c"] = ns.GenerateCSource(headerName)
+
+	return nil
+}
+
+// load parses the namespace of the target, makes it the active one and scans
+// the target package for hand-written symbols. It returns the package name and
+// the path of the generated Go file.
+func (t target) load() (pkgName, goPath string, err error) {
+	nsIndex, err := parseNamespace(t.GIR, t.Namespace, t.Version)
+	if err != nil {
+		return "", "", err
+	}
+
+	ns := gir.SetActiveNamespace(nsIndex)
+
+	pkgName = t.Package
+	if pkgName == "" {
+		pkgName = strings.ToLower(ns.Name)
+	}
+
+	goPath, err = outputPath(t.Output, pkgName)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Skip whatever is hand-written in the target package.
+	if err := gir.ScanManualDecls(filepath.Dir(goPath), goPath); err != nil {
+		return "", "", err
+	}
+
+	return pkgName, goPath, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/gspell/internal/gir"
+)
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	sep := string(filepath.Separator)
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     manifest
+		err      string
+	}{{
+		name: "relative paths",
+		manifest: `{"packages": [
+			{"gir": "Sample-1.0.gir", "import_path": "example.com/sample", "output": "out/"},
+			{"gir": "/abs/Other-1.0.gir", "import_path": "example.com/other", "output": "other.go"}
+		]}`,
+		want: manifest{
+			Runtime: gir.RuntimePath,
+			Packages: []target{{
+				GIR:        filepath.Join(dir, "Sample-1.0.gir"),
+				ImportPath: "example.com/sample",
+				Output:     filepath.Join(dir, "out") + sep,
+			}, {
+				GIR:        "/abs/Other-1.0.gir",
+				ImportPath: "example.com/other",
+				Output:     filepath.Join(dir, "other.go"),
+			}},
+		},
+	}, {
+		name:     "runtime",
+		manifest: `{"runtime": "example.com/runtime", "packages": []}`,
+		want:     manifest{Runtime: "example.com/runtime", Packages: []target{}},
+	}, {
+		name:     "bad JSON",
+		manifest: `{"packages": [`,
+		err:      "Failed to decode manifest",
+	}, {
+		name:     "unknown field",
+		manifest: `{"packages": [], "extra": true}`,
+		err:      "Failed to decode manifest",
+	}, {
+		name:     "missing gir",
+		manifest: `{"packages": [{"import_path": "example.com/sample"}]}`,
+		err:      "needs both gir and import_path",
+	}, {
+		name:     "missing import path",
+		manifest: `{"packages": [{"gir": "Sample-1.0.gir"}]}`,
+		err:      "needs both gir and import_path",
+	}, {
+		name:     "stdin",
+		manifest: `{"packages": [{"gir": "-", "import_path": "example.com/sample"}]}`,
+		err:      "can't read its gir from stdin",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, "girgen.json")
+			if err := ioutil.WriteFile(path, []byte(test.manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readManifest(path)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("got error %v, want %q", err, test.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	if _, err := readManifest(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("a missing manifest was read")
+	}
+}
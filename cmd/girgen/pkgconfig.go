@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/diamondburned/gspell/internal/gir"
+)
+
+// pkgConfigVariable returns the value of a pkg-config variable, or an empty
+// string if the package or the variable doesn't exist.
+func pkgConfigVariable(pkg, variable string) string {
+	out, err := exec.Command("pkg-config", "--variable="+variable, pkg).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// pkgGirDirs returns the gir directories that pkg-config knows of for the given
+// package. Packages rarely set girdir themselves, so the one from
+// gobject-introspection is also used.
+func pkgGirDirs(pkg string) []string {
+	var dirs []string
+	for _, name := range []string{pkg, "gobject-introspection-1.0"} {
+		if dir := pkgConfigVariable(name, "girdir"); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// findPkgGir finds the gir file whose <package> is the given pkg-config
+// package. The includes of the gir file are then found next to it.
+func findPkgGir(pkg string) (string, error) {
+	var dirs []string
+	dirs = append(dirs, gir.GirDirs...)
+	dirs = append(dirs, pkgGirDirs(pkg)...)
+	dirs = append(dirs, gir.DefaultGirDirs()...)
+
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.gir"))
+
+		for _, match := range matches {
+			if girHasPackage(match, pkg) {
+				return match, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no gir file found for package %s", pkg)
+}
+
+func girHasPackage(path, pkg string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header, err := gir.ParseRepositoryHeader(f)
+	if err != nil {
+		log.Println("Failed to parse", path+":", err)
+		return false
+	}
+
+	for _, p := range header.Packages {
+		if p.Name == pkg {
+			return true
+		}
+	}
+
+	return false
+}
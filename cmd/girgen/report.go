@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/diamondburned/gspell/internal/gir"
+)
+
+// report prints the coverage report of a namespace.
+func report(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	format := fs.String("format", "text", "output format, either text or json")
+	namespace := fs.String("namespace", "", "name of the namespace to report on")
+	version := fs.String("version", "", "version of the namespace to report on")
+	output := fs.String("o", "",
+		"output file or directory of the generated code, used to find hand-written files")
+	pkgName := fs.String("package", "", "Go package name of the generated code")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: girgen report [flags] file.gir")
+		fs.PrintDefaults()
+	}
+
+	fs.Parse(args)
+
+	var girPath = fs.Arg(0)
+	if girPath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	t := target{
+		GIR:       girPath,
+		Namespace: *namespace,
+		Version:   *version,
+		Package:   *pkgName,
+		Output:    *output,
+	}
+
+	// Generate without writing anything, so that the report knows which
+	// symbols are hand-written and which fail to generate.
+	gir.KeepGoing = true
+
+	if err := t.generate(map[string][]byte{}); err != nil {
+		log.Fatalln(err)
+	}
+
+	r := gir.ActiveNamespace().Report()
+
+	var err error
+
+	switch *format {
+	case "text":
+		err = r.WriteText(os.Stdout)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(r)
+	default:
+		log.Fatalln("Unknown format:", *format)
+	}
+
+	if err != nil {
+		log.Fatalln("Failed to write report:", err)
+	}
+}
@@ -0,0 +1,6 @@
+package sample
+
+// Add is hand-written, so the dump marks Counter.add as manual.
+func (c *Counter) Add(n int) int {
+	return c.add(n)
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each hunk.
+const diffContext = 3
+
+// diffOp is a single line of a line-based diff. Kind is either ' ', '-' or '+'.
+type diffOp struct {
+	kind byte
+	line string
+
+	// aLine and bLine are the 0-indexed line numbers in a and b before the
+	// operation is applied.
+	aLine int
+	bLine int
+}
+
+// unifiedDiff returns the unified diff between a and b, or an empty string if
+// they're equal.
+func unifiedDiff(aName, bName string, a, b []byte) string {
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	var buf strings.Builder
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", aName, bName)
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk until more unchanged lines than two contexts
+		// separate it from the next change, as GNU diff does.
+		end := i
+		for j := i; j < len(ops) && j-end-1 <= 2*diffContext; j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			}
+		}
+
+		stop := end + diffContext + 1
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		writeHunk(&buf, ops[start:stop])
+		i = stop
+	}
+
+	return buf.String()
+}
+
+func writeHunk(buf *strings.Builder, ops []diffOp) {
+	var aCount, bCount int
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n",
+		hunkRange(ops[0].aLine, aCount), hunkRange(ops[0].bLine, bCount))
+
+	for _, op := range ops {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+
+		if !strings.HasSuffix(op.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the range of a hunk. Empty ranges refer to the line before
+// them, as in GNU diff.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line+1)
+	}
+	return fmt.Sprintf("%d,%d", line+1, count)
+}
+
+// splitLines splits s into lines, keeping their line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes the shortest edit script from a to b using Myers'
+// algorithm.
+func diffLines(a, b []string) []diffOp {
+	var n, m = len(a), len(b)
+	var max = n + m
+
+	// v maps the diagonal k to the furthest x reached on it, offset by
+	// max+1 so that k-1 and k+1 are always in range.
+	var offset = max + 1
+	var v = make([]int, 2*max+3)
+
+	// trace holds the part of v that each round reads from, which is enough
+	// to backtrack.
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var ops []diffOp
+	var x, y = n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		// vAt reads the diagonal k from the snapshot of this round.
+		vAt := func(k int) int { return trace[d][k+d+1] }
+
+		var k = x - y
+		var prevK int
+		if k == -d || (k != d && vAt(k-1) < vAt(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		var prevX = vAt(prevK)
+		var prevY = prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: ' ', line: a[x]})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				ops = append(ops, diffOp{kind: '+', line: b[y]})
+			} else {
+				x--
+				ops = append(ops, diffOp{kind: '-', line: a[x]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	// Reverse the operations and fill in the line numbers.
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	var aLine, bLine int
+	for i := range ops {
+		ops[i].aLine = aLine
+		ops[i].bLine = bLine
+
+		if ops[i].kind != '+' {
+			aLine++
+		}
+		if ops[i].kind != '-' {
+			bLine++
+		}
+	}
+
+	return ops
+}
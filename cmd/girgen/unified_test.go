@@ -0,0 +1,124 @@
+package main
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{{
+		name: "equal",
+		a:    "a\nb\n",
+		b:    "a\nb\n",
+		want: "",
+	}, {
+		name: "change",
+		a:    "1\n2\n3\n4\n5\n6\n7\n8\n",
+		b:    "1\n2\n3\n4\nfive\n6\n7\n8\n",
+		want: "--- a\n+++ b\n" +
+			"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+	}, {
+		name: "from empty",
+		a:    "",
+		b:    "x\ny\n",
+		want: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+	}, {
+		name: "to empty",
+		a:    "x\n",
+		b:    "",
+		want: "--- a\n+++ b\n@@ -1 +0,0 @@\n-x\n",
+	}, {
+		name: "no newline at end",
+		a:    "x\ny",
+		b:    "x\ny\n",
+		want: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n x\n-y\n\\ No newline at end of file\n+y\n",
+	}, {
+		name: "two hunks",
+		a:    "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+		b:    "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+		want: "--- a\n+++ b\n" +
+			"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+			"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+	}, {
+		name: "close changes share a hunk",
+		a:    "a\n1\n2\n3\n4\n5\n6\nb\n",
+		b:    "A\n1\n2\n3\n4\n5\n6\nB\n",
+		want: "--- a\n+++ b\n" +
+			"@@ -1,8 +1,8 @@\n-a\n+A\n 1\n 2\n 3\n 4\n 5\n 6\n-b\n+B\n",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := unifiedDiff("a", "b", []byte(test.a), []byte(test.b))
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+// TestUnifiedDiffPatch applies random diffs with patch and checks that the
+// result is the new file.
+func TestUnifiedDiffPatch(t *testing.T) {
+	patchBin, err := exec.LookPath("patch")
+	if err != nil {
+		t.Skip("patch command not found")
+	}
+
+	var rng = rand.New(rand.NewSource(1))
+
+	randomFile := func() string {
+		var lines []string
+		for i, n := 0, rng.Intn(30); i < n; i++ {
+			lines = append(lines, string(rune('a'+rng.Intn(4))))
+		}
+
+		text := strings.Join(lines, "\n")
+		if len(lines) > 0 && rng.Intn(4) > 0 {
+			text += "\n"
+		}
+		return text
+	}
+
+	dir := t.TempDir()
+
+	for i := 0; i < 200; i++ {
+		a, b := randomFile(), randomFile()
+		diff := unifiedDiff("file", "file", []byte(a), []byte(b))
+		if a == b {
+			if diff != "" {
+				t.Fatalf("diff of equal files isn't empty:\n%s", diff)
+			}
+			continue
+		}
+
+		var path = filepath.Join(dir, "file")
+		if err := ioutil.WriteFile(path, []byte(a), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := exec.Command(patchBin, "-s", "-u", "-N", "-F0", path)
+		cmd.Stdin = strings.NewReader(diff)
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("patch failed: %v\n%s\ndiff of %q and %q:\n%s", err, out, a, b, diff)
+		}
+
+		got, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != b {
+			t.Fatalf("patched %q into %q, want %q; diff:\n%s", a, got, b, diff)
+		}
+	}
+}
@@ -0,0 +1,558 @@
+package gspell
+
+// #include <gtk/gtk.h>
+import "C"
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// Range is a range of bytes in a text.
+type Range struct {
+	Start int
+	End   int
+}
+
+// IgnoreRule returns the ranges of text that must not be spell checked. The
+// ranges may overlap and be in any order.
+type IgnoreRule func(text string) []Range
+
+// IgnoreRegexp returns a rule that ignores all matches of re.
+func IgnoreRegexp(re *regexp.Regexp) IgnoreRule {
+	return func(text string) []Range {
+		var ranges []Range
+		for _, match := range re.FindAllStringIndex(text, -1) {
+			ranges = append(ranges, Range{match[0], match[1]})
+		}
+		return ranges
+	}
+}
+
+// IgnoreWords returns a rule that ignores the words for which ignore returns
+// true. Words are split like the inline checker does: runs of letters, digits
+// and underscores, including single apostrophes and dashes between them.
+func IgnoreWords(ignore func(word string) bool) IgnoreRule {
+	return func(text string) []Range {
+		var ranges []Range
+		for _, word := range splitWords(text) {
+			if ignore(text[word.Start:word.End]) {
+				ranges = append(ranges, word)
+			}
+		}
+		return ranges
+	}
+}
+
+var (
+	urlRegex   = regexp.MustCompile(`(?i)\b(?:[a-z][a-z0-9+.-]*://|www\.)[^\s<>"]+`)
+	emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+)
+
+// urlTrailing is the punctuation that ends a sentence rather than a URL.
+const urlTrailing = `.,;:!?'")]}`
+
+// urlBrackets maps closing brackets to their opening ones.
+var urlBrackets = map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+// trimURL trims the trailing punctuation of the URL. A closing bracket is kept
+// if it closes one opened inside the URL, as in
+// "https://en.wikipedia.org/wiki/Foo_(bar)".
+func trimURL(url string) string {
+	for len(url) > 0 {
+		last := url[len(url)-1]
+		if !strings.ContainsRune(urlTrailing, rune(last)) {
+			break
+		}
+
+		if open, ok := urlBrackets[last]; ok {
+			if strings.Count(url, string(open)) >= strings.Count(url, string(last)) {
+				break
+			}
+		}
+
+		url = url[:len(url)-1]
+	}
+
+	return url
+}
+
+// Built-in ignore rules.
+var (
+	// IgnoreURLs ignores URLs with a scheme, such as "https://gnome.org", and
+	// addresses starting with "www.".
+	IgnoreURLs IgnoreRule = func(text string) []Range {
+		ranges := IgnoreRegexp(urlRegex)(text)
+		for i, r := range ranges {
+			ranges[i].End = r.Start + len(trimURL(text[r.Start:r.End]))
+		}
+		return ranges
+	}
+
+	// IgnoreEmails ignores email addresses.
+	IgnoreEmails = IgnoreRegexp(emailRegex)
+
+	// IgnoreIdentifiers ignores words that look like identifiers in code:
+	// words with underscores, such as "snake_case", and words with an upper
+	// case letter after a lower case one, such as "camelCase".
+	IgnoreIdentifiers = IgnoreWords(isIdentifier)
+
+	// IgnoreAcronyms ignores words of at least two letters that are all upper
+	// case, such as "HTTP".
+	IgnoreAcronyms = IgnoreWords(isAcronym)
+
+	// IgnoreWordsWithDigits ignores words that have both letters and digits,
+	// such as "utf8". Words made only of digits are already skipped by gspell.
+	IgnoreWordsWithDigits = IgnoreWords(hasLetterAndDigit)
+)
+
+func isIdentifier(word string) bool {
+	if strings.ContainsRune(word, '_') {
+		return true
+	}
+
+	var lastLower bool
+	for _, r := range word {
+		if lastLower && unicode.IsUpper(r) {
+			return true
+		}
+		lastLower = unicode.IsLower(r)
+	}
+
+	return false
+}
+
+func isAcronym(word string) bool {
+	var letters int
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if !unicode.IsUpper(r) {
+			return false
+		}
+		letters++
+	}
+
+	return letters >= 2
+}
+
+func hasLetterAndDigit(word string) bool {
+	var letter, digit bool
+	for _, r := range word {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+
+	return letter && digit
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func isApostropheOrDash(r rune) bool {
+	return r == '-' || r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// splitWords splits text into words.
+func splitWords(text string) []Range {
+	var words []Range
+	var start = -1
+
+	for i, r := range text {
+		switch {
+		case isWordRune(r):
+			if start == -1 {
+				start = i
+			}
+
+		case start != -1 && isApostropheOrDash(r):
+			// Keep the apostrophe or dash only if a word continues after it.
+			next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+			if !isWordRune(next) {
+				words = append(words, Range{start, i})
+				start = -1
+			}
+
+		case start != -1:
+			words = append(words, Range{start, i})
+			start = -1
+		}
+	}
+
+	if start != -1 {
+		words = append(words, Range{start, len(text)})
+	}
+
+	return words
+}
+
+// Filter is a pipeline of ignore rules deciding which parts of a text aren't
+// spell checked. It applies to Checker.CheckTextFiltered and to the inline
+// checking of TextViews through Attach. The inline checking of Entries is out
+// of scope, since gspell offers no way to skip parts of an Entry.
+type Filter struct {
+	rules []IgnoreRule
+}
+
+// NewFilter creates a filter with the given rules.
+func NewFilter(rules ...IgnoreRule) *Filter {
+	return &Filter{rules: rules}
+}
+
+// DefaultFilter creates a filter with all built-in rules.
+func DefaultFilter() *Filter {
+	return NewFilter(
+		IgnoreURLs,
+		IgnoreEmails,
+		IgnoreIdentifiers,
+		IgnoreAcronyms,
+		IgnoreWordsWithDigits,
+	)
+}
+
+// Add adds rules to the filter.
+func (f *Filter) Add(rules ...IgnoreRule) {
+	f.rules = append(f.rules, rules...)
+}
+
+// Ranges returns the ranges of text ignored by any rule. The returned ranges
+// are sorted and don't overlap.
+func (f *Filter) Ranges(text string) []Range {
+	var ranges []Range
+	for _, rule := range f.rules {
+		ranges = append(ranges, rule(text)...)
+	}
+
+	return mergeRanges(ranges)
+}
+
+// mergeRanges sorts the ranges and merges the overlapping ones. Empty ranges
+// are dropped.
+func mergeRanges(ranges []Range) []Range {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	var merged []Range
+	for _, r := range ranges {
+		if r.Start >= r.End {
+			continue
+		}
+
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			if r.End > merged[n-1].End {
+				merged[n-1].End = r.End
+			}
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// CheckTextFiltered checks text like CheckText, but drops the misspelled words
+// that overlap a range ignored by the filter.
+func (c *Checker) CheckTextFiltered(text string, filter *Filter) ([]Misspelling, error) {
+	misspellings, err := c.CheckText(text)
+	if err != nil || len(misspellings) == 0 {
+		return misspellings, err
+	}
+
+	ignored := filter.Ranges(text)
+	kept := misspellings[:0]
+
+	// Both are sorted, so walk them together.
+	var i int
+	for _, m := range misspellings {
+		for i < len(ignored) && ignored[i].End <= m.Start {
+			i++
+		}
+
+		if i < len(ignored) && ignored[i].Start < m.End {
+			continue
+		}
+
+		kept = append(kept, m)
+	}
+
+	return kept, nil
+}
+
+// NoSpellCheckTag is the name of the text tag whose text the inline checker of
+// TextView skips. GtkSourceView also uses it for code contexts, so filters
+// shouldn't be attached to a GtkSourceBuffer.
+const NoSpellCheckTag = "gtksourceview:context-classes:no-spell-check"
+
+// Attach applies the filter to the inline checking of the given buffer. The
+// ignored ranges are tagged with NoSpellCheckTag, and the tags of the changed
+// lines are updated whenever the buffer changes, until the returned function is
+// called. The rules are applied line by line, so their ranges can't span lines.
+//
+// Only the tags applied by the filter are removed, so the buffer can have other
+// ranges tagged with NoSpellCheckTag, unless they overlap the filter's. For the
+// same reason, a buffer shouldn't have several filters attached.
+//
+// Entry has no equivalent of the tag, so its inline checking can't be
+// filtered.
+func (f *Filter) Attach(buffer *gtk.TextBuffer) (detach func()) {
+	return f.attach(buffer, false).detach
+}
+
+// taggedBuffer keeps the no-spell-check tags of a buffer up to date, re-tagging
+// only the lines affected by each change.
+type taggedBuffer struct {
+	filter *Filter
+	buffer *gtk.TextBuffer
+	// markdown is true if the lines are also scanned as Markdown.
+	markdown bool
+
+	// noSpellCheck is NoSpellCheckTag, and own is an anonymous tag marking the
+	// ranges that the filter tagged with it.
+	noSpellCheck *gtk.TextTag
+	own          *gtk.TextTag
+
+	// states holds the Markdown state at the start of each line.
+	states []mdState
+	// dirty is the first line changed since the last scan, or -1.
+	dirty int
+
+	handles []glib.SignalHandle
+}
+
+func (f *Filter) attach(buffer *gtk.TextBuffer, markdown bool) *taggedBuffer {
+	t := &taggedBuffer{
+		filter:   f,
+		buffer:   buffer,
+		markdown: markdown,
+		dirty:    -1,
+	}
+
+	table, err := buffer.GetTagTable()
+	if err != nil {
+		return t
+	}
+
+	t.noSpellCheck, err = table.Lookup(NoSpellCheckTag)
+	if err != nil {
+		if t.noSpellCheck, err = gtk.TextTagNew(NoSpellCheckTag); err != nil {
+			return t
+		}
+		table.Add(t.noSpellCheck)
+	}
+
+	if t.own, err = gtk.TextTagNew(""); err != nil {
+		return t
+	}
+	table.Add(t.own)
+
+	t.retag()
+
+	t.handles = []glib.SignalHandle{
+		buffer.Connect("insert-text", func(_ *gtk.TextBuffer, iter *gtk.TextIter) {
+			t.markDirty(iter.GetLine())
+		}),
+		buffer.Connect("delete-range", func(_ *gtk.TextBuffer, start, end *gtk.TextIter) {
+			t.markDirty(start.GetLine())
+		}),
+		buffer.Connect("changed", t.changed),
+	}
+
+	return t
+}
+
+// detach stops updating the tags and removes the ones applied by the filter.
+func (t *taggedBuffer) detach() {
+	if t.own == nil {
+		return
+	}
+
+	for _, handle := range t.handles {
+		t.buffer.HandlerDisconnect(handle)
+	}
+
+	start, end := t.buffer.GetBounds()
+	t.untag(start, end)
+
+	if table, err := t.buffer.GetTagTable(); err == nil {
+		table.Remove(t.own)
+	}
+	t.own = nil
+}
+
+// retag re-tags the whole buffer, for when the rules accept different words.
+func (t *taggedBuffer) retag() {
+	if t.own == nil {
+		return
+	}
+
+	t.states = make([]mdState, t.buffer.GetLineCount())
+	t.scan(0, len(t.states))
+}
+
+func (t *taggedBuffer) markDirty(line int) {
+	if t.dirty == -1 || line < t.dirty {
+		t.dirty = line
+	}
+}
+
+// changed is called after each insertion and deletion.
+func (t *taggedBuffer) changed() {
+	var from = t.dirty
+	t.dirty = -1
+
+	var lines = t.buffer.GetLineCount()
+	if from == -1 || from >= len(t.states) {
+		// The change wasn't seen, so start over.
+		t.retag()
+		return
+	}
+
+	var to int
+	t.states, to = shiftStates(t.states, from, lines)
+	t.scan(from, to)
+}
+
+// shiftStates resizes states to the new number of lines after a change from the
+// line from. Lines are inserted or removed after it, and the inserted ones are
+// marked unknown. It returns the end of the lines that must be scanned again.
+func shiftStates(states []mdState, from, lines int) ([]mdState, int) {
+	var delta = lines - len(states)
+
+	switch {
+	case delta > 0:
+		added := make([]mdState, delta)
+		for i := range added {
+			added[i].unknown = true
+		}
+
+		states = append(states[:from+1], append(added, states[from+1:]...)...)
+
+	case delta < 0:
+		states = append(states[:from+1], states[from+1-delta:]...)
+	}
+
+	var to = from + 1
+	if delta > 0 {
+		to += delta
+	}
+
+	return states, to
+}
+
+// scan re-tags the lines from the given line, at least until the line to, and
+// then, for Markdown, until the state of the next line stops changing.
+func (t *taggedBuffer) scan(from, to int) {
+	scanStates(t.states, from, to, t.tagLine)
+}
+
+// tagLine re-tags a line that starts in the given state, and returns the state
+// of the next line.
+func (t *taggedBuffer) tagLine(line int, state mdState) mdState {
+	text, start, end := t.line(line)
+
+	var ranges []Range
+	var next mdState
+	if t.markdown {
+		ranges, next = scanMarkdownLine(text, state)
+	}
+	ranges = append(ranges, t.filter.Ranges(text)...)
+
+	t.untag(start, end)
+
+	for _, r := range mergeRanges(ranges) {
+		start := t.buffer.GetIterAtLineIndex(line, r.Start)
+		end := t.buffer.GetIterAtLineIndex(line, r.End)
+
+		t.buffer.ApplyTag(t.noSpellCheck, start, end)
+		t.buffer.ApplyTag(t.own, start, end)
+	}
+
+	return next
+}
+
+// scanStates calls scanLine with the lines from the given line and their
+// states, at least until the line to, and then until the state of the next
+// line stops changing. scanLine returns the state of the next line.
+func scanStates(states []mdState, from, to int, scanLine func(line int, state mdState) mdState) {
+	for line := from; line < len(states); line++ {
+		next := scanLine(line, states[line])
+
+		if line+1 == len(states) {
+			break
+		}
+
+		if line+1 >= to && states[line+1] == next {
+			break
+		}
+
+		states[line+1] = next
+	}
+}
+
+// untag removes NoSpellCheckTag from the ranges between start and end that the
+// filter tagged.
+func (t *taggedBuffer) untag(start, end *gtk.TextIter) {
+	var iter = *start
+	if !iter.HasTag(t.own) {
+		iter.ForwardToTagToggle(t.own)
+	}
+
+	for iter.Compare(end) < 0 {
+		var runStart = iter
+		iter.ForwardToTagToggle(t.own)
+		if iter.Compare(end) > 0 {
+			iter = *end
+		}
+
+		t.buffer.RemoveTag(t.noSpellCheck, &runStart, &iter)
+
+		// Skip to the start of the next run.
+		iter.ForwardToTagToggle(t.own)
+	}
+
+	t.buffer.RemoveTag(t.own, start, end)
+}
+
+// line returns the text of the line without its delimiter, which is one of
+// the delimiters of GtkTextBuffer, and the iterators around the line including
+// the delimiter.
+func (t *taggedBuffer) line(line int) (string, *gtk.TextIter, *gtk.TextIter) {
+	start := t.buffer.GetIterAtLine(line)
+
+	var end *gtk.TextIter
+	if line+1 < len(t.states) {
+		end = t.buffer.GetIterAtLine(line + 1)
+	} else {
+		end = t.buffer.GetEndIter()
+	}
+
+	text := bufferSlice(t.buffer, start, end)
+	text = strings.TrimRight(text, "\r\n\u2029")
+
+	return text, start, end
+}
+
+// bufferSlice returns the text between start and end, including the character
+// that stands for embedded images and widgets, so that offsets match.
+func bufferSlice(buffer *gtk.TextBuffer, start, end *gtk.TextIter) string {
+	cstr := C.gtk_text_buffer_get_slice(
+		(*C.GtkTextBuffer)(unsafe.Pointer(buffer.Native())),
+		(*C.GtkTextIter)(unsafe.Pointer(start)),
+		(*C.GtkTextIter)(unsafe.Pointer(end)),
+		C.TRUE,
+	)
+	defer C.g_free(C.gpointer(unsafe.Pointer(cstr)))
+
+	return C.GoString(cstr)
+}
@@ -0,0 +1,154 @@
+package gspell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"hello", []string{"hello"}},
+		{"hello, world.", []string{"hello", "world"}},
+		{"doesn't it", []string{"doesn't", "it"}},
+		{"doesn’t", []string{"doesn’t"}},
+		{"well-known", []string{"well-known"}},
+		{"trailing- 'quoted' dash-", []string{"trailing", "quoted", "dash"}},
+		{"a--b", []string{"a", "b"}},
+		{"snake_case utf8 42", []string{"snake_case", "utf8", "42"}},
+		{"«Größe» 日本語", []string{"Größe", "日本語"}},
+		{"café", []string{"café"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, r := range splitWords(test.text) {
+			got = append(got, test.text[r.Start:r.End])
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestMergeRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []Range
+		want   []Range
+	}{{
+		name: "empty",
+	}, {
+		name:   "sorted",
+		ranges: []Range{{5, 7}, {0, 2}, {3, 4}},
+		want:   []Range{{0, 2}, {3, 4}, {5, 7}},
+	}, {
+		name:   "overlapping",
+		ranges: []Range{{0, 5}, {3, 8}},
+		want:   []Range{{0, 8}},
+	}, {
+		name:   "contained",
+		ranges: []Range{{0, 10}, {2, 4}},
+		want:   []Range{{0, 10}},
+	}, {
+		name:   "adjacent",
+		ranges: []Range{{4, 6}, {0, 4}},
+		want:   []Range{{0, 6}},
+	}, {
+		name:   "empty ranges dropped",
+		ranges: []Range{{3, 3}, {5, 4}, {6, 7}},
+		want:   []Range{{6, 7}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mergeRanges(test.ranges); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsIdentifier(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"word", false},
+		{"Word", false},
+		{"WORD", false},
+		{"snake_case", true},
+		{"_private", true},
+		{"camelCase", true},
+		{"PascalCase", true},
+		{"iPhone", true},
+		{"McDonald", true},
+		{"ÉCOLE", false},
+		{"straßeStadt", true},
+	}
+
+	for _, test := range tests {
+		if got := isIdentifier(test.word); got != test.want {
+			t.Errorf("isIdentifier(%q) = %v, want %v", test.word, got, test.want)
+		}
+	}
+}
+
+func TestIsAcronym(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"HTTP", true},
+		{"OK", true},
+		{"I", false},
+		{"A-B", true},
+		{"Http", false},
+		{"MP3", true},
+		{"X11", false},
+		{"123", false},
+		{"ÉTÉ", true},
+	}
+
+	for _, test := range tests {
+		if got := isAcronym(test.word); got != test.want {
+			t.Errorf("isAcronym(%q) = %v, want %v", test.word, got, test.want)
+		}
+	}
+}
+
+func TestIgnoreURLs(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no links here", nil},
+		{"See https://gnome.org.", []string{"https://gnome.org"}},
+		{"(see https://gnome.org/a_b)", []string{"https://gnome.org/a_b"}},
+		{"Is it www.gnome.org?!", []string{"www.gnome.org"}},
+		{`"ftp://host/file.txt", then`, []string{"ftp://host/file.txt"}},
+		{"<https://gnome.org>", []string{"https://gnome.org"}},
+		{"https://a.org/x, https://b.org/y;", []string{"https://a.org/x", "https://b.org/y"}},
+		{"HTTPS://GNOME.ORG/", []string{"HTTPS://GNOME.ORG/"}},
+		{"See https://en.wikipedia.org/wiki/Foo_(bar).", []string{"https://en.wikipedia.org/wiki/Foo_(bar)"}},
+		{"(https://en.wikipedia.org/wiki/Foo_(bar))", []string{"https://en.wikipedia.org/wiki/Foo_(bar)"}},
+		{"[https://x.org/a[1]]", []string{"https://x.org/a[1]"}},
+		{"(see https://x.org/a))", []string{"https://x.org/a"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, r := range IgnoreURLs(test.text) {
+			got = append(got, test.text[r.Start:r.End])
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("IgnoreURLs(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
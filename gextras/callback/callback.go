@@ -0,0 +1,92 @@
+// Package callback is a registry of the Go callbacks passed to C as user data.
+//
+// Callbacks are looked up without locking, and handles of deleted callbacks
+// stay invalid when their slots are reused; see Handle.
+package callback
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+var live = new(int64) // number of assigned callbacks
+
+// Assign assigns the callback and returns its handle, which is passed to C as
+// the user data.
+func Assign(callback interface{}) Handle {
+	return assign(callback, nil, false, 1)
+}
+
+// AssignOnce assigns the callback like Assign, but deletes it the first time
+// Get returns it. Get returns it only once, even when called concurrently. This is for callbacks that C calls only once, such as the
+// ones of asynchronous functions.
+func AssignOnce(callback interface{}) Handle {
+	return assign(callback, nil, true, 1)
+}
+
+// assign assigns the callback. Obj is the GObject weakly referenced by
+// AssignObject, if any. Skip is the number of frames between the caller to
+// record in debug mode and assign's caller.
+func assign(callback interface{}, obj unsafe.Pointer, once bool, skip int) Handle {
+	var e = &entry{once: once, value: callback, obj: obj}
+
+	// Record the site before the entry is published, so that it's never seen
+	// without one.
+	if debugEnabled() {
+		e.site = record(skip + 1)
+	}
+
+	shard := atomic.AddUint32(&nextShard, 1) % numShards
+	h := shards[shard].assign(shard, e)
+	atomic.AddInt64(live, 1)
+
+	return h
+}
+
+// Get returns the callback of the handle, or nil if it was deleted.
+func Get(h Handle) interface{} {
+	shard, slot, gen := h.split()
+
+	value, once := shards[shard].get(slot, gen)
+	if !once {
+		return value
+	}
+
+	// Only the caller that deletes the callback gets it, so that concurrent
+	// calls never both get it.
+	if e := remove(h); e != nil {
+		return e.value
+	}
+
+	return nil
+}
+
+// Delete deletes the callback of the handle. Deleting it again does nothing.
+// The weak reference added by AssignObject is removed along with it.
+func Delete(h Handle) {
+	if e := remove(h); e != nil && e.obj != nil {
+		weakUnref(e.obj, h)
+	}
+}
+
+// weakUnref removes the weak reference that AssignObject added to obj. It is
+// set by object.go, since only cgo builds have AssignObject.
+var weakUnref func(obj unsafe.Pointer, h Handle)
+
+// remove deletes the callback of the handle and returns its entry, or nil if
+// it was already deleted.
+func remove(h Handle) *entry {
+	shard, slot, gen := h.split()
+
+	e := shards[shard].delete(slot, gen)
+	if e != nil {
+		atomic.AddInt64(live, -1)
+	}
+
+	return e
+}
+
+// Len returns the number of callbacks that are still assigned.
+func Len() int {
+	return int(atomic.LoadInt64(live))
+}
@@ -0,0 +1,233 @@
+package callback
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// syncMapRegistry is the previous registry, kept to compare against. Like it,
+// it counts the live callbacks.
+type syncMapRegistry struct {
+	registry sync.Map
+	serial   uintptr
+	live     int64
+}
+
+func (r *syncMapRegistry) Assign(callback interface{}) uintptr {
+	id := atomic.AddUintptr(&r.serial, 1)
+	r.registry.Store(id, callback)
+	atomic.AddInt64(&r.live, 1)
+	return id
+}
+
+func (r *syncMapRegistry) Get(ptr uintptr) interface{} {
+	v, _ := r.registry.Load(ptr)
+	return v
+}
+
+func (r *syncMapRegistry) Delete(ptr uintptr) {
+	if _, ok := r.registry.LoadAndDelete(ptr); ok {
+		atomic.AddInt64(&r.live, -1)
+	}
+}
+
+type wordFunc func(string) bool
+
+var benchFunc wordFunc = func(string) bool { return true }
+
+// benchLive is the number of callbacks kept assigned during the benchmarks.
+const benchLive = 1024
+
+func BenchmarkAssignDelete(b *testing.B) {
+	b.Run("registry", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Delete(Assign(benchFunc))
+		}
+	})
+
+	b.Run("syncmap", func(b *testing.B) {
+		var r syncMapRegistry
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r.Delete(r.Assign(benchFunc))
+		}
+	})
+}
+
+func BenchmarkAssignDeleteParallel(b *testing.B) {
+	b.Run("registry", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				Delete(Assign(benchFunc))
+			}
+		})
+	})
+
+	b.Run("syncmap", func(b *testing.B) {
+		var r syncMapRegistry
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				r.Delete(r.Assign(benchFunc))
+			}
+		})
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.Run("registry", func(b *testing.B) {
+		handles := make([]Handle, benchLive)
+		for i := range handles {
+			handles[i] = Assign(benchFunc)
+		}
+		defer func() {
+			for _, h := range handles {
+				Delete(h)
+			}
+		}()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			Get(handles[i%benchLive]).(wordFunc)("")
+		}
+	})
+
+	b.Run("syncmap", func(b *testing.B) {
+		var r syncMapRegistry
+		ids := make([]uintptr, benchLive)
+		for i := range ids {
+			ids[i] = r.Assign(benchFunc)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Get(ids[i%benchLive]).(wordFunc)("")
+		}
+	})
+}
+
+func BenchmarkGetParallel(b *testing.B) {
+	b.Run("registry", func(b *testing.B) {
+		handles := make([]Handle, benchLive)
+		for i := range handles {
+			handles[i] = Assign(benchFunc)
+		}
+		defer func() {
+			for _, h := range handles {
+				Delete(h)
+			}
+		}()
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				Get(handles[i%benchLive]).(wordFunc)("")
+			}
+		})
+	})
+
+	b.Run("syncmap", func(b *testing.B) {
+		var r syncMapRegistry
+		ids := make([]uintptr, benchLive)
+		for i := range ids {
+			ids[i] = r.Assign(benchFunc)
+		}
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				r.Get(ids[i%benchLive]).(wordFunc)("")
+			}
+		})
+	})
+}
+
+// BenchmarkMixedParallel gets callbacks while others are assigned and deleted,
+// which is what a busy application does.
+func BenchmarkMixedParallel(b *testing.B) {
+	b.Run("registry", func(b *testing.B) {
+		handles := make([]Handle, benchLive)
+		for i := range handles {
+			handles[i] = Assign(benchFunc)
+		}
+		defer func() {
+			for _, h := range handles {
+				Delete(h)
+			}
+		}()
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%8 == 0 {
+					Delete(Assign(benchFunc))
+					continue
+				}
+				Get(handles[i%benchLive]).(wordFunc)("")
+			}
+		})
+	})
+
+	b.Run("syncmap", func(b *testing.B) {
+		var r syncMapRegistry
+		ids := make([]uintptr, benchLive)
+		for i := range ids {
+			ids[i] = r.Assign(benchFunc)
+		}
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%8 == 0 {
+					r.Delete(r.Assign(benchFunc))
+					continue
+				}
+				r.Get(ids[i%benchLive]).(wordFunc)("")
+			}
+		})
+	})
+}
+
+func TestAssignOnce(t *testing.T) {
+	h := AssignOnce(benchFunc)
+
+	if Get(h) == nil {
+		t.Fatal("Get returned nil for a new callback")
+	}
+	if Get(h) != nil {
+		t.Fatal("Get returned the callback again after its first invocation")
+	}
+}
+
+func TestAssignOnceConcurrent(t *testing.T) {
+	const getters = 8
+
+	for i := 0; i < 1000; i++ {
+		h := AssignOnce(benchFunc)
+
+		var got int32
+		var start, done sync.WaitGroup
+		start.Add(1)
+		done.Add(getters)
+
+		for j := 0; j < getters; j++ {
+			go func() {
+				defer done.Done()
+				start.Wait()
+				if Get(h) != nil {
+					atomic.AddInt32(&got, 1)
+				}
+			}()
+		}
+
+		start.Done()
+		done.Wait()
+
+		if got != 1 {
+			t.Fatalf("the callback was returned %d times, want once", got)
+		}
+	}
+}
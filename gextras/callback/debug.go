@@ -0,0 +1,197 @@
+package callback
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DebugEnv is the environment variable that enables debug mode if it's set to a
+// non-empty value.
+const DebugEnv = "GEXTRAS_CALLBACK_DEBUG"
+
+var debugMode = func() int32 {
+	if os.Getenv(DebugEnv) != "" {
+		return 1
+	}
+	return 0
+}()
+
+// SetDebug enables or disables debug mode. In debug mode, Assign records the
+// stack of its caller and the time, which Snapshot then reports. Callbacks
+// assigned while debug mode is off are reported with an empty stack.
+func SetDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&debugMode, v)
+}
+
+func debugEnabled() bool {
+	return atomic.LoadInt32(&debugMode) == 1
+}
+
+// maxStackDepth is the maximum number of frames recorded for each callback.
+const maxStackDepth = 32
+
+// siteInfo is what debug mode records for each callback.
+type siteInfo struct {
+	stack []uintptr
+	time  time.Time
+}
+
+// record returns the site of the caller of the function skip frames above
+// record.
+func record(skip int) *siteInfo {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+
+	return &siteInfo{
+		stack: append([]uintptr(nil), pcs[:n]...),
+		time:  time.Now(),
+	}
+}
+
+// Site is a call site of Assign along with the callbacks that it assigned and
+// that are still alive.
+type Site struct {
+	// Caller is the function and the position that called Assign, or empty if
+	// the callbacks were assigned while debug mode was off.
+	Caller string
+	// Stack is the formatted stack trace of the oldest callback.
+	Stack string
+	Count int
+	// Oldest is the time that the oldest callback was assigned at.
+	Oldest time.Time
+}
+
+func (s Site) String() string {
+	if s.Caller == "" {
+		return fmt.Sprintf("%d callback(s) assigned outside debug mode", s.Count)
+	}
+
+	return fmt.Sprintf("%d callback(s) assigned by %s since %s:\n%s",
+		s.Count, s.Caller, s.Oldest.Format(time.RFC3339), s.Stack)
+}
+
+// Snapshot returns the callbacks that are still alive, grouped by call site.
+// Sites with the most callbacks come first.
+func Snapshot() []Site {
+	return snapshot(nil)
+}
+
+// snapshot returns the sites of the callbacks, skipping those in the given set.
+func snapshot(skip map[Handle]bool) []Site {
+	var bySite = map[string]*Site{}
+	var order []*Site
+
+	rangeEntries(func(h Handle, e *entry) {
+		if skip[h] {
+			return
+		}
+
+		var info siteInfo
+		if e.site != nil {
+			info = *e.site
+		}
+
+		var caller = formatCaller(info.stack)
+
+		site, ok := bySite[caller]
+		if !ok {
+			site = &Site{Caller: caller, Stack: formatStack(info.stack), Oldest: info.time}
+			bySite[caller] = site
+			order = append(order, site)
+		}
+
+		site.Count++
+		if info.time.Before(site.Oldest) {
+			site.Oldest = info.time
+			site.Stack = formatStack(info.stack)
+		}
+	})
+
+	snapshot := make([]Site, len(order))
+	for i, site := range order {
+		snapshot[i] = *site
+	}
+
+	sort.SliceStable(snapshot, func(i, j int) bool {
+		if snapshot[i].Count != snapshot[j].Count {
+			return snapshot[i].Count > snapshot[j].Count
+		}
+		return snapshot[i].Caller < snapshot[j].Caller
+	})
+
+	return snapshot
+}
+
+func formatCaller(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:1]).Next()
+	return fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+}
+
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+
+	return b.String()
+}
+
+// TB is the part of testing.TB that VerifyNone uses.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// VerifyNone fails the test if any callback assigned from now on is still
+// alive when the test and its subtests end. Enable debug mode to see where the
+// leaked callbacks were assigned.
+//
+// Since the registry is global, tests calling VerifyNone shouldn't run in
+// parallel with other tests that assign callbacks.
+func VerifyNone(t TB) {
+	t.Helper()
+
+	var before = map[Handle]bool{}
+	rangeEntries(func(h Handle, _ *entry) { before[h] = true })
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		leaked := snapshot(before)
+		if len(leaked) == 0 {
+			return
+		}
+
+		var b strings.Builder
+		for _, site := range leaked {
+			b.WriteString("\n")
+			b.WriteString(site.String())
+		}
+
+		t.Errorf("callback: leaked callbacks:%s", b.String())
+	})
+}
@@ -0,0 +1,118 @@
+package callback
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeTB records the errors of VerifyNone and runs its cleanups on finish.
+type fakeTB struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTB) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func withDebug(t *testing.T) {
+	old := debugEnabled()
+	SetDebug(true)
+	t.Cleanup(func() { SetDebug(old) })
+}
+
+func assignFromA() Handle { return Assign(benchFunc) }
+func assignFromB() Handle { return Assign(benchFunc) }
+
+func TestLen(t *testing.T) {
+	before := Len()
+
+	a := Assign(benchFunc)
+	b := AssignOnce(benchFunc)
+	if n := Len() - before; n != 2 {
+		t.Errorf("got %d new callbacks, want 2", n)
+	}
+
+	Delete(a)
+	Delete(a)
+	Get(b)
+	if n := Len() - before; n != 0 {
+		t.Errorf("got %d callbacks left, want 0", n)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	withDebug(t)
+
+	handles := []Handle{assignFromA(), assignFromA(), assignFromB()}
+	defer func() {
+		for _, h := range handles {
+			Delete(h)
+		}
+	}()
+
+	counts := map[string]int{}
+	for _, site := range Snapshot() {
+		for _, name := range []string{"assignFromA", "assignFromB"} {
+			if strings.Contains(site.Caller, name) {
+				counts[name] += site.Count
+
+				if !strings.Contains(site.Stack, "TestSnapshot") {
+					t.Errorf("the stack of %s doesn't contain the test:\n%s", name, site.Stack)
+				}
+			}
+		}
+	}
+
+	if counts["assignFromA"] != 2 || counts["assignFromB"] != 1 {
+		t.Errorf("got counts %v, want 2 for assignFromA and 1 for assignFromB", counts)
+	}
+
+	// Deleted callbacks are no longer reported.
+	Delete(handles[0])
+	for _, site := range Snapshot() {
+		if strings.Contains(site.Caller, "assignFromA") && site.Count != 1 {
+			t.Errorf("got %d callbacks from assignFromA after a delete, want 1", site.Count)
+		}
+	}
+}
+
+func TestVerifyNone(t *testing.T) {
+	withDebug(t)
+
+	existing := Assign(benchFunc)
+	defer Delete(existing)
+
+	// Callbacks assigned before VerifyNone and deleted ones aren't leaks.
+	var clean fakeTB
+	VerifyNone(&clean)
+	Delete(assignFromA())
+	clean.finish()
+
+	if len(clean.errors) > 0 {
+		t.Errorf("VerifyNone failed without a leak: %q", clean.errors)
+	}
+
+	var leaking fakeTB
+	VerifyNone(&leaking)
+	leaked := assignFromB()
+	leaking.finish()
+	Delete(leaked)
+
+	if len(leaking.errors) != 1 || !strings.Contains(leaking.errors[0], "assignFromB") {
+		t.Errorf("VerifyNone didn't report the leak from assignFromB: %q", leaking.errors)
+	}
+}
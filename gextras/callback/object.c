@@ -0,0 +1,14 @@
+#include "object.h"
+#include "_cgo_export.h"
+
+void callback_weak_ref(GObject *obj, uintptr_t handle) {
+	g_object_weak_ref(obj, callback_weak_notify, (gpointer)handle);
+}
+
+void callback_weak_unref(GObject *obj, uintptr_t handle) {
+	g_object_weak_unref(obj, callback_weak_notify, (gpointer)handle);
+}
+
+void callback_weak_notify(gpointer data, GObject *where_the_object_was) {
+	callbackWeakNotify((uintptr_t)data);
+}
@@ -0,0 +1,44 @@
+package callback
+
+// #cgo pkg-config: gobject-2.0
+// #include "object.h"
+import "C"
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// weakRefs is the number of weak references added by AssignObject that are
+// still held.
+var weakRefs int64
+
+func init() {
+	weakUnref = func(obj unsafe.Pointer, h Handle) {
+		C.callback_weak_unref((*C.GObject)(obj), C.uintptr_t(h))
+		atomic.AddInt64(&weakRefs, -1)
+	}
+}
+
+// AssignObject assigns the callback like Assign, but also ties its lifetime to
+// the given GObject: the callback, along with everything it captures, is
+// deleted once the object is finalized. The object is not referenced.
+//
+// Deleting the callback earlier is still allowed, and removes the weak
+// reference from the object.
+func AssignObject(obj unsafe.Pointer, callback interface{}) Handle {
+	h := assign(callback, obj, false, 1)
+
+	C.callback_weak_ref((*C.GObject)(obj), C.uintptr_t(h))
+	atomic.AddInt64(&weakRefs, 1)
+
+	return h
+}
+
+//export callbackWeakNotify
+func callbackWeakNotify(handle C.uintptr_t) {
+	// GLib drops the weak reference before notifying, so only the callback is
+	// left to delete.
+	atomic.AddInt64(&weakRefs, -1)
+	remove(Handle(handle))
+}
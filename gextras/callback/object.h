@@ -0,0 +1,12 @@
+#include <stdint.h>
+#include <glib-object.h>
+
+// Deletes the callback of the handle once the object is finalized. The handle
+// is only a pointer on the C side.
+void callback_weak_ref(GObject *obj, uintptr_t handle);
+
+// Removes the weak reference added by callback_weak_ref, once the callback is
+// deleted before the object is finalized.
+void callback_weak_unref(GObject *obj, uintptr_t handle);
+
+void callback_weak_notify(gpointer data, GObject *where_the_object_was);
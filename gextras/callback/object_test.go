@@ -0,0 +1,34 @@
+//go:build cgo
+// +build cgo
+
+package callback
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+func TestAssignObjectDelete(t *testing.T) {
+	action := glib.SimpleActionNew("test", nil)
+	obj := unsafe.Pointer(action.Native())
+
+	before := atomic.LoadInt64(&weakRefs)
+
+	// Connecting and disconnecting doesn't build up weak references, and
+	// deleting again does nothing.
+	for i := 0; i < 1000; i++ {
+		h := AssignObject(obj, benchFunc)
+		Delete(h)
+		Delete(h)
+	}
+
+	if n := atomic.LoadInt64(&weakRefs) - before; n != 0 {
+		t.Errorf("%d weak references are left", n)
+	}
+
+	runtime.KeepAlive(action)
+}
@@ -0,0 +1,61 @@
+package callback
+
+import (
+	"errors"
+	"log"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// ErrNotFound is panicked by generated code if C calls a callback that isn't
+// in the registry.
+var ErrNotFound = errors.New("callback not found")
+
+// Panic is a panic recovered from a callback called by C.
+type Panic struct {
+	// Callback is the name of the callback type.
+	Callback string
+	// Value is the value given to panic.
+	Value interface{}
+	// Stack is the Go stack of the panic.
+	Stack []byte
+}
+
+// PanicHandler handles a panic recovered from a callback.
+type PanicHandler func(Panic)
+
+var panicHandler atomic.Value // PanicHandler
+
+func init() {
+	panicHandler.Store(PanicHandler(LogPanic))
+}
+
+// SetPanicHandler sets the handler called when a callback panics. The default
+// handler is LogPanic.
+func SetPanicHandler(h PanicHandler) {
+	if h == nil {
+		h = LogPanic
+	}
+	panicHandler.Store(h)
+}
+
+// LogPanic logs the panic along with its stack.
+func LogPanic(p Panic) {
+	log.Printf("callback: panic in %s: %v\n%s", p.Callback, p.Value, p.Stack)
+}
+
+// Recover is deferred by the exported functions that C calls. Panics must not
+// unwind through C frames, so Recover gives them to the panic handler instead,
+// and the exported function then returns the zero value.
+func Recover(callback string) {
+	v := recover()
+	if v == nil {
+		return
+	}
+
+	panicHandler.Load().(PanicHandler)(Panic{
+		Callback: callback,
+		Value:    v,
+		Stack:    debug.Stack(),
+	})
+}
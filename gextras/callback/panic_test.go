@@ -0,0 +1,121 @@
+package callback
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withPanics makes the panic handler collect the panics, and restores the
+// default one after the test.
+func withPanics(t *testing.T) *[]Panic {
+	var panics []Panic
+	SetPanicHandler(func(p Panic) { panics = append(panics, p) })
+	t.Cleanup(func() { SetPanicHandler(nil) })
+	return &panics
+}
+
+// trampoline is shaped like the exported functions that girgen generates for
+// callbacks.
+func trampoline(handle uintptr, word string) bool {
+	defer Recover("WordFunc")
+
+	fn := Get(Handle(handle))
+	if fn == nil {
+		panic(ErrNotFound)
+	}
+
+	return fn.(wordFunc)(word)
+}
+
+func TestRecover(t *testing.T) {
+	panics := withPanics(t)
+
+	func() {
+		defer Recover("WordFunc")
+		panic("boom")
+	}()
+
+	if len(*panics) != 1 {
+		t.Fatalf("got %d panics, want 1", len(*panics))
+	}
+
+	p := (*panics)[0]
+	if p.Callback != "WordFunc" || p.Value != "boom" {
+		t.Errorf("got panic %q in %q", p.Value, p.Callback)
+	}
+	if !strings.Contains(string(p.Stack), "TestRecover") {
+		t.Errorf("the stack doesn't contain the panicking function:\n%s", p.Stack)
+	}
+
+	// Returning normally doesn't call the handler.
+	func() {
+		defer Recover("WordFunc")
+	}()
+
+	if len(*panics) != 1 {
+		t.Errorf("got %d panics after returning normally, want 1", len(*panics))
+	}
+}
+
+func TestLogPanicDefault(t *testing.T) {
+	isLogPanic := func() bool {
+		h := panicHandler.Load().(PanicHandler)
+		return reflect.ValueOf(h).Pointer() == reflect.ValueOf(LogPanic).Pointer()
+	}
+
+	if !isLogPanic() {
+		t.Fatal("the default handler isn't LogPanic")
+	}
+
+	SetPanicHandler(func(Panic) {})
+	SetPanicHandler(nil)
+	if !isLogPanic() {
+		t.Fatal("a nil handler doesn't restore LogPanic")
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	func() {
+		defer Recover("WordFunc")
+		panic("boom")
+	}()
+
+	if !strings.Contains(buf.String(), "callback: panic in WordFunc: boom") {
+		t.Errorf("LogPanic logged %q", buf.String())
+	}
+}
+
+func TestTrampolineZeroValue(t *testing.T) {
+	panics := withPanics(t)
+
+	ok := Assign(wordFunc(func(string) bool { return true }))
+	defer Delete(ok)
+
+	if !trampoline(uintptr(ok), "word") {
+		t.Error("the trampoline didn't return the value of the callback")
+	}
+
+	panicking := Assign(wordFunc(func(string) bool { panic("boom") }))
+	defer Delete(panicking)
+
+	if trampoline(uintptr(panicking), "word") {
+		t.Error("the trampoline didn't return false after a panic")
+	}
+
+	deleted := Assign(benchFunc)
+	Delete(deleted)
+
+	if trampoline(uintptr(deleted), "word") {
+		t.Error("the trampoline didn't return false for a deleted callback")
+	}
+
+	if len(*panics) != 2 || (*panics)[0].Value != "boom" || (*panics)[1].Value != ErrNotFound {
+		t.Errorf("got panics %v", *panics)
+	}
+}
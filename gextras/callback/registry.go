@@ -0,0 +1,204 @@
+package callback
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Handle identifies a callback in the registry. It holds the slot of the
+// callback and the generation of the slot, so a handle stays invalid once its
+// callback is deleted, even after the slot is reused. The zero Handle is never
+// valid.
+//
+// The generation is in the low 12 bits, so a stale handle only becomes valid
+// again after its slot is reused 4095 times. Free slots are reused in the order
+// they were freed to make that rare.
+//
+// Handles are passed to C as pointers, so they are kept between 1<<16 and 1<<32
+// on all platforms: the Go runtime rejects pointers below 4096, and the Go heap
+// is above 4 GiB on 64-bit platforms.
+type Handle uintptr
+
+const (
+	genBits = 12
+	genMask = 1<<genBits - 1
+
+	shardBits = 4
+	numShards = 1 << shardBits
+
+	// slotBits is what remains of 32 bits. Slots are stored plus one, so that
+	// the smallest handle is 1<<16.
+	slotBits = 32 - genBits - shardBits
+	maxSlots = 1<<slotBits - 1
+
+	chunkBits = 8
+	chunkSize = 1 << chunkBits
+)
+
+func makeHandle(shard, slot, gen uint32) Handle {
+	return Handle(uintptr(slot+1)<<(genBits+shardBits) | uintptr(shard)<<genBits | uintptr(gen))
+}
+
+// split splits the handle. The slot of the zero Handle is out of range.
+func (h Handle) split() (shard, slot, gen uint32) {
+	return uint32(h>>genBits) & (numShards - 1),
+		uint32(h>>(genBits+shardBits)) - 1,
+		uint32(h) & genMask
+}
+
+// entry is an assigned callback. Entries are never modified, so they can be
+// read without locking.
+type entry struct {
+	gen   uint32
+	once  bool
+	value interface{}
+	// obj is the GObject that AssignObject tied the callback to, or nil.
+	obj unsafe.Pointer
+	// site is where the callback was assigned, or nil outside debug mode.
+	site *siteInfo
+}
+
+// chunk is a fixed block of slots, each holding an *entry or nil.
+type chunk [chunkSize]unsafe.Pointer
+
+// shard is a part of the registry. Writes lock the shard, while reads only
+// load atomically.
+type shard struct {
+	mu sync.Mutex
+	// chunks is a *[]*chunk. The slice is copied when it grows, so readers
+	// always see a consistent one.
+	chunks unsafe.Pointer
+	// gens is the last generation of each slot.
+	gens []uint32
+	// free is the queue of free slots, oldest first.
+	free []uint32
+
+	// Keep shards on different cache lines.
+	_ [64]byte
+}
+
+var (
+	shards    [numShards]shard
+	nextShard uint32
+)
+
+func (s *shard) loadChunks() []*chunk {
+	if p := atomic.LoadPointer(&s.chunks); p != nil {
+		return *(*[]*chunk)(p)
+	}
+	return nil
+}
+
+// slot returns the pointer to the slot. It returns nil if the slot was never
+// allocated.
+func (s *shard) slot(slot uint32) *unsafe.Pointer {
+	chunks := s.loadChunks()
+	if i := int(slot >> chunkBits); i < len(chunks) {
+		return &chunks[i][slot&(chunkSize-1)]
+	}
+	return nil
+}
+
+// alloc returns a free slot. s.mu must be held.
+func (s *shard) alloc() uint32 {
+	if len(s.free) > 0 {
+		slot := s.free[0]
+		s.free = s.free[1:]
+		return slot
+	}
+
+	slot := uint32(len(s.gens))
+	if slot >= maxSlots {
+		panic("callback: registry is full")
+	}
+
+	s.gens = append(s.gens, 0)
+
+	if chunks := s.loadChunks(); int(slot>>chunkBits) >= len(chunks) {
+		grown := make([]*chunk, len(chunks)+1)
+		copy(grown, chunks)
+		grown[len(chunks)] = new(chunk)
+		atomic.StorePointer(&s.chunks, unsafe.Pointer(&grown))
+	}
+
+	return slot
+}
+
+// assign stores the entry in a free slot and returns its handle. The entry is
+// only published once its generation is set.
+func (s *shard) assign(index uint32, e *entry) Handle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot := s.alloc()
+
+	gen := (s.gens[slot] + 1) & genMask
+	if gen == 0 {
+		gen = 1
+	}
+	s.gens[slot] = gen
+
+	e.gen = gen
+	atomic.StorePointer(s.slot(slot), unsafe.Pointer(e))
+
+	return makeHandle(index, slot, gen)
+}
+
+// get returns the value in the slot, and whether it must be deleted now that
+// it's fetched.
+func (s *shard) get(slot, gen uint32) (value interface{}, once bool) {
+	p := s.slot(slot)
+	if p == nil {
+		return nil, false
+	}
+
+	e := (*entry)(atomic.LoadPointer(p))
+	if e == nil || e.gen != gen {
+		return nil, false
+	}
+
+	return e.value, e.once
+}
+
+// delete empties the slot and returns the deleted entry, or nil if there was
+// none.
+func (s *shard) delete(slot, gen uint32) *entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.slot(slot)
+	if p == nil {
+		return nil
+	}
+
+	e := (*entry)(atomic.LoadPointer(p))
+	if e == nil || e.gen != gen {
+		return nil
+	}
+
+	atomic.StorePointer(p, nil)
+	s.free = append(s.free, slot)
+
+	return e
+}
+
+// rangeEntries calls fn on every assigned callback. Callbacks assigned or
+// deleted concurrently may or may not be seen.
+func rangeEntries(fn func(h Handle, e *entry)) {
+	for i := range shards {
+		s := &shards[i]
+
+		for c, chunk := range s.loadChunks() {
+			for j := range chunk {
+				e := (*entry)(atomic.LoadPointer(&chunk[j]))
+				if e == nil {
+					continue
+				}
+
+				slot := uint32(c<<chunkBits | j)
+				fn(makeHandle(uint32(i), slot, e.gen), e)
+			}
+		}
+	}
+}
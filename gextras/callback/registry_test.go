@@ -0,0 +1,130 @@
+package callback
+
+import "testing"
+
+func TestStaleHandle(t *testing.T) {
+	n := Len()
+
+	h := Assign(benchFunc)
+	if Get(h) == nil {
+		t.Fatal("Get returned nil for a new callback")
+	}
+
+	Delete(h)
+	if Get(h) != nil {
+		t.Fatal("Get returned a deleted callback")
+	}
+
+	// Deleting again must not delete the callback that reuses the slot.
+	other := Assign(benchFunc)
+	defer Delete(other)
+
+	Delete(h)
+	if Get(other) == nil {
+		t.Fatal("deleting a stale handle deleted another callback")
+	}
+
+	if Len() != n+1 {
+		t.Fatalf("Len = %d, want %d", Len(), n+1)
+	}
+}
+
+func TestSlotReuse(t *testing.T) {
+	var s shard
+
+	old := s.assign(3, &entry{value: "old"})
+	_, oldSlot, oldGen := old.split()
+	s.delete(oldSlot, oldGen)
+
+	h := s.assign(3, &entry{value: "new"})
+	shard, slot, gen := h.split()
+
+	if shard != 3 || slot != oldSlot {
+		t.Fatalf("got shard %d slot %d, want shard 3 slot %d", shard, slot, oldSlot)
+	}
+	if gen == oldGen {
+		t.Fatalf("the reused slot kept generation %d", gen)
+	}
+
+	if v, _ := s.get(oldSlot, oldGen); v != nil {
+		t.Errorf("the stale handle got %v", v)
+	}
+	if v, _ := s.get(slot, gen); v != "new" {
+		t.Errorf("the new handle got %v, want new", v)
+	}
+}
+
+func TestSlotReuseOrder(t *testing.T) {
+	var s shard
+
+	a := s.assign(0, &entry{value: "a"})
+	b := s.assign(0, &entry{value: "b"})
+
+	_, slotA, genA := a.split()
+	_, slotB, genB := b.split()
+	s.delete(slotA, genA)
+	s.delete(slotB, genB)
+
+	// The slot freed first is reused first.
+	if _, slot, _ := s.assign(0, &entry{value: "c"}).split(); slot != slotA {
+		t.Errorf("got slot %d, want %d", slot, slotA)
+	}
+}
+
+func TestGenerationWraparound(t *testing.T) {
+	var s shard
+
+	first := s.assign(0, &entry{value: 0})
+	_, slot, firstGen := first.split()
+	s.delete(slot, firstGen)
+
+	for i := 1; i < genMask; i++ {
+		h := s.assign(0, &entry{value: i})
+
+		_, hSlot, gen := h.split()
+		if hSlot != slot {
+			t.Fatalf("reuse %d got slot %d, want %d", i, hSlot, slot)
+		}
+		if gen == 0 || gen == firstGen {
+			t.Fatalf("reuse %d got generation %d", i, gen)
+		}
+		if v, _ := s.get(slot, firstGen); v != nil {
+			t.Fatalf("the first handle got %v after %d reuses", v, i)
+		}
+
+		s.delete(slot, gen)
+	}
+
+	// The generation skips zero and wraps around after genMask reuses, which
+	// is when a stale handle becomes valid again.
+	if h := s.assign(0, &entry{value: "last"}); h != first {
+		t.Errorf("got handle %#x after wrapping around, want %#x", h, first)
+	}
+}
+
+func TestHandleRange(t *testing.T) {
+	if v := Get(0); v != nil {
+		t.Errorf("Get(0) = %v", v)
+	}
+	Delete(0)
+
+	tests := []struct {
+		shard, slot, gen uint32
+	}{
+		{0, 0, 1},
+		{numShards - 1, maxSlots - 1, genMask},
+	}
+
+	for _, test := range tests {
+		h := makeHandle(test.shard, test.slot, test.gen)
+		if h < 1<<16 || uint64(h) >= 1<<32 {
+			t.Errorf("handle %#x is out of range", h)
+		}
+
+		shard, slot, gen := h.split()
+		if shard != test.shard || slot != test.slot || gen != test.gen {
+			t.Errorf("%#x split into %d, %d, %d; want %d, %d, %d",
+				h, shard, slot, gen, test.shard, test.slot, test.gen)
+		}
+	}
+}
@@ -1,7 +1,6 @@
-package gspell
+package gextras
 
-// #include <gtk/gtk.h>
-// #include "util.h"
+// #include "gextras.h"
 import "C"
 
 import (
@@ -14,41 +13,11 @@ import (
 	"github.com/gotk3/gotk3/gtk"
 )
 
-func gpointer(ptr unsafe.Pointer) C.gpointer {
-	return C.conptr(ptr)
-}
-
-func nwidget(w gtk.IWidget) unsafe.Pointer {
+// WidgetPtr returns the native GtkWidget pointer of w.
+func WidgetPtr(w gtk.IWidget) unsafe.Pointer {
 	return unsafe.Pointer(w.ToWidget().Native())
 }
 
-func cwidget(w gtk.IWidget) *C.GtkWidget {
-	return (*C.GtkWidget)(nwidget(w))
-}
-
-func gwidget(w gtk.IWidget) C.gpointer {
-	return gpointer(unsafe.Pointer(w.ToWidget().GObject))
-}
-
-func widget(obj *glib.Object) *gtk.Widget {
-	return &gtk.Widget{glib.InitiallyUnowned{obj}}
-}
-
-func container(obj *glib.Object) gtk.Container {
-	return gtk.Container{gtk.Widget{glib.InitiallyUnowned{obj}}}
-}
-
-func cbool(val bool) C.gboolean {
-	if val {
-		return C.TRUE
-	}
-	return C.FALSE
-}
-
-func gobool(val C.gboolean) bool {
-	return val != C.FALSE
-}
-
 // These functions are copied from gotk3. For some reasons, they weren't
 // exported?
 
@@ -86,17 +55,18 @@ func castInternal(className string, obj *glib.Object) (interface{}, error) {
 	return rv[0].Interface(), nil
 }
 
-// cast takes a native GObject and casts it to the appropriate Go struct.
-//TODO change all wrapFns to return an IObject
-//^- not sure about this TODO. This may make some usages of the wrapper functions quite verbose, no?
-func cast(c *C.GObject) (glib.IObject, error) {
-	ptr := unsafe.Pointer(c)
+func castPtr(ptr unsafe.Pointer) (interface{}, error) {
 	var (
-		className = C.GoString(C.object_get_class_name(c))
+		className = C.GoString(C.object_get_class_name((*C.GObject)(ptr)))
 		obj       = glib.Take(ptr)
 	)
 
-	intf, err := castInternal(className, obj)
+	return castInternal(className, obj)
+}
+
+// CastObject takes a native GObject and casts it to the appropriate Go struct.
+func CastObject(ptr unsafe.Pointer) (glib.IObject, error) {
+	intf, err := castPtr(ptr)
 	if err != nil {
 		return nil, err
 	}
@@ -109,15 +79,10 @@ func cast(c *C.GObject) (glib.IObject, error) {
 	return ret, nil
 }
 
-// castWidget takes a native GtkWidget and casts it to the appropriate Go struct.
-func castWidget(c *C.GtkWidget) (gtk.IWidget, error) {
-	ptr := unsafe.Pointer(c)
-	var (
-		className = C.GoString(C.object_get_class_name(C.toGObject(ptr)))
-		obj       = glib.Take(ptr)
-	)
-
-	intf, err := castInternal(className, obj)
+// CastWidget takes a native GtkWidget and casts it to the appropriate Go
+// struct.
+func CastWidget(ptr unsafe.Pointer) (gtk.IWidget, error) {
+	intf, err := castPtr(ptr)
 	if err != nil {
 		return nil, err
 	}
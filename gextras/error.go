@@ -0,0 +1,35 @@
+package gextras
+
+// #include "gextras.h"
+import "C"
+
+import "unsafe"
+
+// Error is a GError copied into Go.
+type Error struct {
+	// Domain is the name of the error domain quark.
+	Domain  string
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// TakeError converts the given GError to an *Error and frees it. Nil is
+// returned if ptr is nil.
+func TakeError(ptr unsafe.Pointer) error {
+	if ptr == nil {
+		return nil
+	}
+
+	gerr := (*C.GError)(ptr)
+	defer C.g_error_free(gerr)
+
+	return &Error{
+		Domain:  C.GoString(C.g_quark_to_string(gerr.domain)),
+		Code:    int(gerr.code),
+		Message: C.GoString(gerr.message),
+	}
+}
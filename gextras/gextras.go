@@ -0,0 +1,27 @@
+// Package gextras contains the runtime support used by code generated by
+// girgen. Generated code from any module imports it instead of relying on
+// helpers inside its own package.
+package gextras
+
+// #cgo pkg-config: gobject-2.0
+// #include "gextras.h"
+import "C"
+
+// SupportPackageIsVersion2 is referenced by generated code to assert at compile
+// time that it is compatible with this package. It is bumped on breaking
+// changes of the API used by generated code.
+const SupportPackageIsVersion2 = true
+
+// Cbool converts val to a gboolean. The result is an int so that it converts to
+// the C.gboolean of any package.
+func Cbool(val bool) int {
+	if val {
+		return C.TRUE
+	}
+	return C.FALSE
+}
+
+// Gobool converts the given gboolean to a Go bool.
+func Gobool(val int) bool {
+	return val != C.FALSE
+}
@@ -0,0 +1,5 @@
+#include <glib-object.h>
+
+static const gchar* object_get_class_name(GObject* object) {
+	return G_OBJECT_CLASS_NAME(G_OBJECT_GET_CLASS(object));
+};
@@ -0,0 +1,27 @@
+package gextras
+
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// WrapList wraps the given GList. The data of the list is converted using wrap
+// if it's not nil. Nil is returned for an empty list.
+func WrapList(ptr unsafe.Pointer, wrap func(unsafe.Pointer) interface{}) *glib.List {
+	list := glib.WrapList(uintptr(ptr))
+	if list != nil && wrap != nil {
+		list.DataWrapper(wrap)
+	}
+	return list
+}
+
+// WrapSList wraps the given GSList. The data of the list is converted using
+// wrap if it's not nil. Nil is returned for an empty list.
+func WrapSList(ptr unsafe.Pointer, wrap func(unsafe.Pointer) interface{}) *glib.SList {
+	list := glib.WrapSList(uintptr(ptr))
+	if list != nil && wrap != nil {
+		list.DataWrapper(wrap)
+	}
+	return list
+}
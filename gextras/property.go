@@ -0,0 +1,25 @@
+package gextras
+
+// #include <stdlib.h>
+// #include "gextras.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// SetPropertyValue sets the property with the given name of obj to value.
+// Unlike (*glib.Object).SetProperty, the GType of the value is kept, which is
+// needed for enum and flags properties. See the generated ToGValue methods.
+func SetPropertyValue(obj *glib.Object, name string, value *glib.Value) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+
+	C.g_object_set_property(
+		(*C.GObject)(unsafe.Pointer(obj.Native())),
+		(*C.gchar)(cstr),
+		(*C.GValue)(unsafe.Pointer(value.Native())),
+	)
+}
@@ -1,6 +1,6 @@
 module github.com/diamondburned/gspell
 
-go 1.15
+go 1.16
 
 replace github.com/gotk3/gotk3 => github.com/diamondburned/gotk3 v0.0.0-20201229054305-848200601f20
 
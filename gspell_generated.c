@@ -0,0 +1,8 @@
+// Code generated by girgen. DO NOT EDIT.
+
+#include "_cgo_export.h"
+#include "gspell_generated.h"
+
+void gspell_trampoline_delete(gpointer data) {
+	gspell_callbackDelete((uintptr_t)data);
+}
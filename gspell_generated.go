@@ -1,20 +1,24 @@
+// Code generated by girgen. DO NOT EDIT.
+
 package gspell
 
 import (
+	"fmt"
+	"github.com/diamondburned/gspell/gextras"
+	"github.com/diamondburned/gspell/gextras/callback"
 	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
 	"unsafe"
 )
 
-// #cgo pkg-config: gspell-1 gtk+-3.0 glib-2.0 gio-2.0 glib-2.0 gobject-2.0
-// #include <gspell/gspell.h>
-// #include <gtk/gtk.h>
-// #include <gio/gio.h>
-// #include <glib.h>
-// #include <glib-object.h>
-//
+// #cgo pkg-config: gspell-1 gtk+-3.0 atk gobject-2.0 glib-2.0 gdk-3.0 cairo-gobject pango gdk-pixbuf-2.0 gio-2.0
+// #include "gspell_generated.h"
 import "C"
 
+// This is a compile-time assertion that the imported gextras package is
+// compatible with the generated code.
+const _ = gextras.SupportPackageIsVersion2
+
 // objector is used internally for other interfaces.
 type objector interface {
 	glib.IObject
@@ -55,7 +59,7 @@ func init() {
 	})
 }
 
-// CheckerError an error code used with GSPELL_CHECKER_ERROR in a #GError
+// CheckerError an error code used with GSPELL_CHECKER_ERROR in a GError
 // returned from a spell-checker-related function.
 type CheckerError int
 
@@ -70,11 +74,92 @@ const (
 	CheckerErrorNoLanguageSet CheckerError = 1
 )
 
+// CheckerErrorValues returns all known values of CheckerError.
+func CheckerErrorValues() []CheckerError {
+	return []CheckerError{CheckerErrorDictionary, CheckerErrorNoLanguageSet}
+}
+
+// nick returns the GLib nick of a single value or an empty string.
+func (c CheckerError) nick() string {
+	switch c {
+	case CheckerErrorDictionary:
+		return "dictionary"
+	case CheckerErrorNoLanguageSet:
+		return "no-language-set"
+	default:
+		return ""
+	}
+}
+
+// parseCheckerError parses a single GLib nick.
+func parseCheckerError(nick string) (CheckerError, bool) {
+	switch nick {
+	case "dictionary":
+		return CheckerErrorDictionary, true
+	case "no-language-set":
+		return CheckerErrorNoLanguageSet, true
+	default:
+		return 0, false
+	}
+}
+
+// String returns the GLib nick of CheckerError.
+func (c CheckerError) String() string {
+	if nick := c.nick(); nick != "" {
+		return nick
+	}
+	return fmt.Sprintf("CheckerError(%d)", int(c))
+}
+
+// IsValid returns true if c is a known CheckerError.
+func (c CheckerError) IsValid() bool {
+	return c.nick() != ""
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c CheckerError) MarshalText() ([]byte, error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("invalid CheckerError %d", int(c))
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *CheckerError) UnmarshalText(text []byte) error {
+	nick := string(text)
+	v, ok := parseCheckerError(nick)
+	if !ok {
+		return fmt.Errorf("unknown CheckerError %q", nick)
+	}
+	*c = v
+	return nil
+}
+
+// ToGValue converts c to a GValue of its GType. The returned value can be given
+// to gextras.SetPropertyValue.
+func (c CheckerError) ToGValue() (*glib.Value, error) {
+	v, err := glib.ValueInit(glib.Type(C.gspell_checker_error_get_type()))
+	if err != nil {
+		return nil, err
+	}
+
+	C.g_value_set_enum((*C.GValue)(unsafe.Pointer(v.Native())), C.gint(c))
+	return v, nil
+}
+
 type LanguageChooserer interface {
 	objector
+	// GetLanguage returns the selected [Language], or nil if no dictionaries are
+	// available.
 	GetLanguage() *Language
+	// GetLanguageCode returns the [LanguageChooser]:language-code. It cannot be
+	// nil.
 	GetLanguageCode() string
 	// SetLanguage sets the selected language.
+	//
+	// Parameters:
+	//
+	//   - language: a [Language] or nil to pick the default language.
 	SetLanguage(language *Language)
 	SetLanguageCode(languageCode string)
 }
@@ -87,16 +172,26 @@ type LanguageChooser struct {
 func (l *LanguageChooser) native() *C.GspellLanguageChooser {
 	return (*C.GspellLanguageChooser)(unsafe.Pointer(l.Native()))
 }
+
+// GetLanguage returns the selected [Language], or nil if no dictionaries are
+// available.
 func (l *LanguageChooser) GetLanguage() *Language {
 	r := (*Language)(C.gspell_language_chooser_get_language(l.native()))
 	return r
 }
+
+// GetLanguageCode returns the [LanguageChooser]:language-code. It cannot be
+// nil.
 func (l *LanguageChooser) GetLanguageCode() string {
 	r := C.GoString(C.gspell_language_chooser_get_language_code(l.native()))
 	return r
 }
 
 // SetLanguage sets the selected language.
+//
+// Parameters:
+//
+//   - language: a [Language] or nil to pick the default language.
 func (l *LanguageChooser) SetLanguage(language *Language) {
 	v1 := (*C.GspellLanguage)(unsafe.Pointer(language.Native()))
 	C.gspell_language_chooser_set_language(l.native(), v1)
@@ -109,18 +204,26 @@ func (l *LanguageChooser) SetLanguageCode(languageCode string) {
 
 type Navigatorer interface {
 	objector
-	// Change changes the current word by change_to in the text. word must be the
+	// Change changes the current word by changeTo in the text. word must be the
 	// same as returned by the last call to C.gspell_navigator_goto_next().
 	//
-	// This function doesn't call (*Checker).SetCorrection(). A widget using a
-	// Navigator should call (*Checker).SetCorrection() in addition to this
-	// function.
+	// This function doesn't call [Checker.SetCorrection]. A widget using a
+	// [Navigator] should call [Checker.SetCorrection] in addition to this function.
+	//
+	// Parameters:
+	//
+	//   - word: the word to change.
+	//   - changeTo: the replacement.
 	Change(word string, changeTo string)
-	// ChangeAll changes all occurrences of word by change_to in the text.
+	// ChangeAll changes all occurrences of word by changeTo in the text.
+	//
+	// This function doesn't call [Checker.SetCorrection]. A widget using a
+	// [Navigator] should call [Checker.SetCorrection] in addition to this function.
 	//
-	// This function doesn't call (*Checker).SetCorrection(). A widget using a
-	// Navigator should call (*Checker).SetCorrection() in addition to this
-	// function.
+	// Parameters:
+	//
+	//   - word: the word to change.
+	//   - changeTo: the replacement.
 	ChangeAll(word string, changeTo string)
 }
 
@@ -133,12 +236,16 @@ func (n *Navigator) native() *C.GspellNavigator {
 	return (*C.GspellNavigator)(unsafe.Pointer(n.Native()))
 }
 
-// Change changes the current word by change_to in the text. word must be the
+// Change changes the current word by changeTo in the text. word must be the
 // same as returned by the last call to C.gspell_navigator_goto_next().
 //
-// This function doesn't call (*Checker).SetCorrection(). A widget using a
-// Navigator should call (*Checker).SetCorrection() in addition to this
-// function.
+// This function doesn't call [Checker.SetCorrection]. A widget using a
+// [Navigator] should call [Checker.SetCorrection] in addition to this function.
+//
+// Parameters:
+//
+//   - word: the word to change.
+//   - changeTo: the replacement.
 func (n *Navigator) Change(word string, changeTo string) {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
@@ -148,11 +255,15 @@ func (n *Navigator) Change(word string, changeTo string) {
 	C.gspell_navigator_change(n.native(), v1, v2)
 }
 
-// ChangeAll changes all occurrences of word by change_to in the text.
+// ChangeAll changes all occurrences of word by changeTo in the text.
+//
+// This function doesn't call [Checker.SetCorrection]. A widget using a
+// [Navigator] should call [Checker.SetCorrection] in addition to this function.
 //
-// This function doesn't call (*Checker).SetCorrection(). A widget using a
-// Navigator should call (*Checker).SetCorrection() in addition to this
-// function.
+// Parameters:
+//
+//   - word: the word to change.
+//   - changeTo: the replacement.
 func (n *Navigator) ChangeAll(word string, changeTo string) {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
@@ -162,13 +273,20 @@ func (n *Navigator) ChangeAll(word string, changeTo string) {
 	C.gspell_navigator_change_all(n.native(), v1, v2)
 }
 
+//export gspell_callbackDelete
+func gspell_callbackDelete(handle C.uintptr_t) {
+	callback.Delete(callback.Handle(handle))
+}
+
 func CheckerErrorQuark() glib.Quark {
 	r := glib.Quark(C.gspell_checker_error_quark())
 	return r
 }
+
+// LanguageGetAvailable returns the list of available languages, sorted with
+// [Language.Compare].
 func LanguageGetAvailable() *glib.List {
-	r := glib.WrapList(uintptr(unsafe.Pointer(C.gspell_language_get_available())))
-	r.DataWrapper(func(ptr unsafe.Pointer) interface{} {
+	r := gextras.WrapList(unsafe.Pointer(C.gspell_language_get_available()), func(ptr unsafe.Pointer) interface{} {
 		val := (*Language)(ptr)
 		return val
 	})
@@ -177,10 +295,19 @@ func LanguageGetAvailable() *glib.List {
 
 // LanguageGetDefault finds the best available language based on the current
 // locale.
+//
+// Returns the default [Language], or nil if no dictionaries are available.
 func LanguageGetDefault() *Language {
 	r := (*Language)(C.gspell_language_get_default())
 	return r
 }
+
+// LanguageLookup returns a [Language] corresponding to languageCode, or nil if
+// not found.
+//
+// Parameters:
+//
+//   - languageCode: a language code.
 func LanguageLookup(languageCode string) *Language {
 	v1 := C.CString(languageCode)
 	defer C.free(unsafe.Pointer(v1))
@@ -192,8 +319,8 @@ type Checker struct {
 	*glib.Object
 }
 
-// wrapChecker wraps the given pointer to *Checker.
-func wrapChecker(ptr unsafe.Pointer) *Checker {
+// WrapChecker wraps the given pointer to *Checker.
+func WrapChecker(ptr unsafe.Pointer) *Checker {
 	obj := glib.Take(ptr)
 	return &Checker{
 		Object: obj,
@@ -201,14 +328,20 @@ func wrapChecker(ptr unsafe.Pointer) *Checker {
 }
 
 func marshalChecker(p uintptr) (interface{}, error) {
-	return wrapChecker(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapChecker(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
-// CheckerNew creates a new Checker. If language is nil, the default language is
-// picked with LanguageGetDefault().
+// CheckerNew creates a new [Checker]. If language is nil, the default language
+// is picked with [LanguageGetDefault].
+//
+// Parameters:
+//
+//   - language: the [Language] to use, or nil.
+//
+// Returns a new [Checker] object. The caller owns the returned value.
 func CheckerNew(language *Language) *Checker {
 	v1 := (*C.GspellLanguage)(unsafe.Pointer(language.Native()))
-	return wrapChecker(unsafe.Pointer(C.gspell_checker_new(v1)))
+	return WrapChecker(unsafe.Pointer(C.gspell_checker_new(v1)))
 }
 
 // native turns the current *Checker into the native C pointer type.
@@ -218,6 +351,11 @@ func (c *Checker) native() *C.GspellChecker {
 
 // AddWordToPersonal adds a word to the personal dictionary. It is typically
 // saved in the user's home directory.
+//
+// Parameters:
+//
+//   - word: a word.
+//   - wordLength: the byte length of word, or -1 if word is nul-terminated.
 func (c *Checker) AddWordToPersonal(word string, wordLength int) {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
@@ -226,12 +364,17 @@ func (c *Checker) AddWordToPersonal(word string, wordLength int) {
 	C.gspell_checker_add_word_to_personal(c.native(), v1, v2)
 }
 
-// AddWordToSession adds a word to the session dictionary. Each Checker instance
-// has a different session dictionary. The session dictionary is lost when the
-// Checker:language property changes or when checker is destroyed or when
-// (*Checker).ClearSession() is called.
+// AddWordToSession adds a word to the session dictionary. Each [Checker]
+// instance has a different session dictionary. The session dictionary is lost
+// when the [Checker]:language property changes or when checker is destroyed or
+// when [Checker.ClearSession] is called.
 //
 // This function is typically called for an “Ignore All” action.
+//
+// Parameters:
+//
+//   - word: a word.
+//   - wordLength: the byte length of word, or -1 if word is nul-terminated.
 func (c *Checker) AddWordToSession(word string, wordLength int) {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
@@ -240,14 +383,21 @@ func (c *Checker) AddWordToSession(word string, wordLength int) {
 	C.gspell_checker_add_word_to_session(c.native(), v1, v2)
 }
 
-// CheckWord if the Checker:language is nil, i.e. when no dictonaries are
+// CheckWord if the [Checker]:language is nil, i.e. when no dictonaries are
 // available, this function returns true to limit the damage.
+//
+// Parameters:
+//
+//   - word: the word to check.
+//   - wordLength: the byte length of word, or -1 if word is nul-terminated.
+//
+// Returns true if word is correctly spelled, false otherwise.
 func (c *Checker) CheckWord(word string, wordLength int) bool {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
 	v2 := C.gssize(wordLength)
 
-	r := gobool(C.gspell_checker_check_word(c.native(), v1, v2, nil))
+	r := gextras.Gobool(int(C.gspell_checker_check_word(c.native(), v1, v2, nil)))
 	return r
 }
 
@@ -257,14 +407,19 @@ func (c *Checker) ClearSession() {
 }
 
 // GetEnchantDict gets the EnchantDict currently used by checker. It permits to
-// extend Checker with more features. Note that by doing so, the other classes
+// extend [Checker] with more features. Note that by doing so, the other classes
 // in gspell may no longer work well.
 //
-// Checker re-creates a new EnchantDict when the Checker:language is changed and
-// when the session is cleared.
+// [Checker] re-creates a new EnchantDict when the [Checker]:language is changed
+// and when the session is cleared.
+//
+// Since: 1.6
 func (c *Checker) GetEnchantDict() {
 	C.gspell_checker_get_enchant_dict(c.native())
 }
+
+// GetLanguage returns the [Language] currently used, or nil if no dictionaries
+// are available.
 func (c *Checker) GetLanguage() *Language {
 	r := (*Language)(C.gspell_checker_get_language(c.native()))
 	return r
@@ -272,17 +427,32 @@ func (c *Checker) GetLanguage() *Language {
 
 // GetSuggestions gets the suggestions for word. Free the return value with
 // g_slist_free_full(suggestions, g_free).
+//
+// Parameters:
+//
+//   - word: a misspelled word.
+//   - wordLength: the byte length of word, or -1 if word is nul-terminated.
+//
+// Returns the list of suggestions. The caller owns the returned value.
 func (c *Checker) GetSuggestions(word string, wordLength int) *glib.SList {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
 	v2 := C.gssize(wordLength)
 
-	r := glib.WrapSList(uintptr(unsafe.Pointer(C.gspell_checker_get_suggestions(c.native(), v1, v2))))
+	r := gextras.WrapSList(unsafe.Pointer(C.gspell_checker_get_suggestions(c.native(), v1, v2)), nil)
 	return r
 }
 
 // SetCorrection informs the spell checker that word is replaced/corrected by
 // replacement.
+//
+// Parameters:
+//
+//   - word: a word.
+//   - wordLength: the byte length of word, or -1 if word is nul-terminated.
+//   - replacement: the replacement word.
+//   - replacementLength: the byte length of replacement, or -1 if replacement
+//     is nul-terminated.
 func (c *Checker) SetCorrection(word string, wordLength int, replacement string, replacementLength int) {
 	v1 := C.CString(word)
 	defer C.free(unsafe.Pointer(v1))
@@ -295,7 +465,11 @@ func (c *Checker) SetCorrection(word string, wordLength int, replacement string,
 }
 
 // SetLanguage sets the language to use for the spell checking. If language is
-// nil, the default language is picked with LanguageGetDefault().
+// nil, the default language is picked with [LanguageGetDefault].
+//
+// Parameters:
+//
+//   - language: the [Language] to use, or nil.
 func (c *Checker) SetLanguage(language *Language) {
 	v1 := (*C.GspellLanguage)(unsafe.Pointer(language.Native()))
 	C.gspell_checker_set_language(c.native(), v1)
@@ -305,8 +479,8 @@ type CheckerDialog struct {
 	gtk.Dialog
 }
 
-// wrapCheckerDialog wraps the given pointer to *CheckerDialog.
-func wrapCheckerDialog(ptr unsafe.Pointer) *CheckerDialog {
+// WrapCheckerDialog wraps the given pointer to *CheckerDialog.
+func WrapCheckerDialog(ptr unsafe.Pointer) *CheckerDialog {
 	obj := glib.Take(ptr)
 	return &CheckerDialog{
 		Dialog: gtk.Dialog{
@@ -326,22 +500,28 @@ func wrapCheckerDialog(ptr unsafe.Pointer) *CheckerDialog {
 }
 
 func marshalCheckerDialog(p uintptr) (interface{}, error) {
-	return wrapCheckerDialog(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapCheckerDialog(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
-// CheckerDialogNew creates a new CheckerDialog.
+// CheckerDialogNew returns a new [CheckerDialog] widget.
+//
+// Parameters:
+//
+//   - parent: transient parent of the dialog.
+//   - navigator: the [Navigator] to use.
 func CheckerDialogNew(parent *gtk.Window, navigator *Navigator) *CheckerDialog {
 	v1 := (*C.GtkWindow)(unsafe.Pointer(parent.Widget.Native()))
 	v2 := (*C.GspellNavigator)(unsafe.Pointer(navigator.Native()))
 
-	return wrapCheckerDialog(unsafe.Pointer(C.gspell_checker_dialog_new(v1, v2)))
+	return WrapCheckerDialog(unsafe.Pointer(C.gspell_checker_dialog_new(v1, v2)))
 }
 
 // native turns the current *CheckerDialog into the native C pointer type.
 func (c *CheckerDialog) native() *C.GspellCheckerDialog {
-	return (*C.GspellCheckerDialog)(gwidget(&c.Dialog))
+	return (*C.GspellCheckerDialog)(gextras.WidgetPtr(&c.Dialog))
 }
 
+// GetSpellNavigator returns the [Navigator] used.
 func (c *CheckerDialog) GetSpellNavigator() *Navigator {
 	obj := glib.Take(unsafe.Pointer(C.gspell_checker_dialog_get_spell_navigator(c.native())))
 	r := &Navigator{obj}
@@ -352,8 +532,8 @@ type Entry struct {
 	*glib.Object
 }
 
-// wrapEntry wraps the given pointer to *Entry.
-func wrapEntry(ptr unsafe.Pointer) *Entry {
+// WrapEntry wraps the given pointer to *Entry.
+func WrapEntry(ptr unsafe.Pointer) *Entry {
 	obj := glib.Take(ptr)
 	return &Entry{
 		Object: obj,
@@ -361,7 +541,7 @@ func wrapEntry(ptr unsafe.Pointer) *Entry {
 }
 
 func marshalEntry(p uintptr) (interface{}, error) {
-	return wrapEntry(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapEntry(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
 // native turns the current *Entry into the native C pointer type.
@@ -369,43 +549,61 @@ func (e *Entry) native() *C.GspellEntry {
 	return (*C.GspellEntry)(unsafe.Pointer(e.Object.Native()))
 }
 
-// GetFromGtkEntry returns the Entry of gtk_entry. The returned object is
-// guaranteed to be the same for the lifetime of gtk_entry.
+// GetFromGtkEntry returns the [Entry] of gtkEntry. The returned object is
+// guaranteed to be the same for the lifetime of gtkEntry.
+//
+// Parameters:
+//
+//   - gtkEntry: a [gtk.Entry].
+//
+// Returns the [Entry] of gtkEntry.
+//
+// Since: 1.4
 func GetFromGtkEntry(gtkEntry *gtk.Entry) *Entry {
 	v1 := (*C.GtkEntry)(unsafe.Pointer(gtkEntry.Widget.Native()))
-	r := wrapEntry(unsafe.Pointer(C.gspell_entry_get_from_gtk_entry(v1)))
+	r := WrapEntry(unsafe.Pointer(C.gspell_entry_get_from_gtk_entry(v1)))
 	return r
 }
 
-// BasicSetup function is a convenience function that does the following: - Set
-// a spell checker. The language chosen is the one returned by
-// LanguageGetDefault(). - Set the Entry:inline-spell-checking property to true.
+// BasicSetup function is a convenience function that does the following:
+//
+//   - Set a spell checker. The language chosen is the one returned by
+//     [LanguageGetDefault].
+//   - Set the [Entry]:inline-spell-checking property to true.
 //
-// Example: |[ GtkEntry *gtk_entry; GspellEntry *gspell_entry;
+// Example:
 //
-//    gspell_entry = gspell_entry_get_from_gtk_entry (gtk_entry);
-//    gspell_entry_basic_setup (gspell_entry);
+//	GtkEntry *gtk_entry;
+//	GspellEntry *gspell_entry;
 //
+//	gspell_entry = gspell_entry_get_from_gtk_entry (gtk_entry);
+//	gspell_entry_basic_setup (gspell_entry);
 //
+// This is equivalent to:
 //
-//    GtkEntry *gtk_entry;
-//    GspellEntry *gspell_entry;
-//    GspellChecker *checker;
-//    GtkEntryBuffer *gtk_buffer;
-//    GspellEntryBuffer *gspell_buffer;
+//	GtkEntry *gtk_entry;
+//	GspellEntry *gspell_entry;
+//	GspellChecker *checker;
+//	GtkEntryBuffer *gtk_buffer;
+//	GspellEntryBuffer *gspell_buffer;
 //
-//    checker = gspell_checker_new (NULL);
-//    gtk_buffer = gtk_entry_get_buffer (gtk_entry);
-//    gspell_buffer = gspell_entry_buffer_get_from_gtk_entry_buffer (gtk_buffer);
-//    gspell_entry_buffer_set_spell_checker (gspell_buffer, checker);
-//    g_object_unref (checker);
+//	checker = gspell_checker_new (NULL);
+//	gtk_buffer = gtk_entry_get_buffer (gtk_entry);
+//	gspell_buffer = gspell_entry_buffer_get_from_gtk_entry_buffer (gtk_buffer);
+//	gspell_entry_buffer_set_spell_checker (gspell_buffer, checker);
+//	g_object_unref (checker);
 //
-//    gspell_entry = gspell_entry_get_from_gtk_entry (gtk_entry);
-//    gspell_entry_set_inline_spell_checking (gspell_entry, TRUE);
+//	gspell_entry = gspell_entry_get_from_gtk_entry (gtk_entry);
+//	gspell_entry_set_inline_spell_checking (gspell_entry, TRUE);
 //
+// Since: 1.4
 func (e *Entry) BasicSetup() {
 	C.gspell_entry_basic_setup(e.native())
 }
+
+// GetEntry returns the [gtk.Entry] of gspellEntry.
+//
+// Since: 1.4
 func (e *Entry) GetEntry() *gtk.Entry {
 	obj := glib.Take(unsafe.Pointer(C.gspell_entry_get_entry(e.native())))
 	r := &gtk.Entry{
@@ -417,14 +615,25 @@ func (e *Entry) GetEntry() *gtk.Entry {
 	}
 	return r
 }
+
+// GetInlineSpellChecking returns the value of the [Entry]:inline-spell-checking
+// property.
+//
+// Since: 1.4
 func (e *Entry) GetInlineSpellChecking() bool {
-	r := gobool(C.gspell_entry_get_inline_spell_checking(e.native()))
+	r := gextras.Gobool(int(C.gspell_entry_get_inline_spell_checking(e.native())))
 	return r
 }
 
-// SetInlineSpellChecking sets the Entry:inline-spell-checking property.
+// SetInlineSpellChecking sets the [Entry]:inline-spell-checking property.
+//
+// Parameters:
+//
+//   - enable: the new state.
+//
+// Since: 1.4
 func (e *Entry) SetInlineSpellChecking(enable bool) {
-	v1 := cbool(enable)
+	v1 := C.gboolean(gextras.Cbool(enable))
 	C.gspell_entry_set_inline_spell_checking(e.native(), v1)
 }
 
@@ -432,8 +641,8 @@ type EntryBuffer struct {
 	*glib.Object
 }
 
-// wrapEntryBuffer wraps the given pointer to *EntryBuffer.
-func wrapEntryBuffer(ptr unsafe.Pointer) *EntryBuffer {
+// WrapEntryBuffer wraps the given pointer to *EntryBuffer.
+func WrapEntryBuffer(ptr unsafe.Pointer) *EntryBuffer {
 	obj := glib.Take(ptr)
 	return &EntryBuffer{
 		Object: obj,
@@ -441,7 +650,7 @@ func wrapEntryBuffer(ptr unsafe.Pointer) *EntryBuffer {
 }
 
 func marshalEntryBuffer(p uintptr) (interface{}, error) {
-	return wrapEntryBuffer(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapEntryBuffer(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
 // native turns the current *EntryBuffer into the native C pointer type.
@@ -449,14 +658,25 @@ func (e *EntryBuffer) native() *C.GspellEntryBuffer {
 	return (*C.GspellEntryBuffer)(unsafe.Pointer(e.Object.Native()))
 }
 
-// GetFromGtkEntryBuffer returns the EntryBuffer of gtk_buffer. The returned
-// object is guaranteed to be the same for the lifetime of gtk_buffer.
+// GetFromGtkEntryBuffer returns the [EntryBuffer] of gtkBuffer. The returned
+// object is guaranteed to be the same for the lifetime of gtkBuffer.
+//
+// Parameters:
+//
+//   - gtkBuffer: a [gtk.EntryBuffer].
+//
+// Returns the [EntryBuffer] of gtkBuffer.
+//
+// Since: 1.4
 func GetFromGtkEntryBuffer(gtkBuffer *gtk.EntryBuffer) *EntryBuffer {
 	v1 := (*C.GtkEntryBuffer)(unsafe.Pointer(gtkBuffer.Native()))
-	r := wrapEntryBuffer(unsafe.Pointer(C.gspell_entry_buffer_get_from_gtk_entry_buffer(v1)))
+	r := WrapEntryBuffer(unsafe.Pointer(C.gspell_entry_buffer_get_from_gtk_entry_buffer(v1)))
 	return r
 }
 
+// GetBuffer returns the [gtk.EntryBuffer] of gspellBuffer.
+//
+// Since: 1.4
 func (e *EntryBuffer) GetBuffer() *gtk.EntryBuffer {
 	obj := glib.Take(unsafe.Pointer(C.gspell_entry_buffer_get_buffer(e.native())))
 	r := &gtk.EntryBuffer{
@@ -464,14 +684,24 @@ func (e *EntryBuffer) GetBuffer() *gtk.EntryBuffer {
 	}
 	return r
 }
+
+// GetSpellChecker returns the [Checker] if one has been set, or nil.
+//
+// Since: 1.4
 func (e *EntryBuffer) GetSpellChecker() *Checker {
-	r := wrapChecker(unsafe.Pointer(C.gspell_entry_buffer_get_spell_checker(e.native())))
+	r := WrapChecker(unsafe.Pointer(C.gspell_entry_buffer_get_spell_checker(e.native())))
 	return r
 }
 
-// SetSpellChecker sets a Checker to a EntryBuffer. The gspell_buffer will own a
-// reference to spell_checker, so you can release your reference to
-// spell_checker if you no longer need it.
+// SetSpellChecker sets a [Checker] to a [EntryBuffer]. The gspellBuffer will
+// own a reference to spellChecker, so you can release your reference to
+// spellChecker if you no longer need it.
+//
+// Parameters:
+//
+//   - spellChecker: a [Checker], or nil to unset the spell checker.
+//
+// Since: 1.4
 func (e *EntryBuffer) SetSpellChecker(spellChecker *Checker) {
 	v1 := (*C.GspellChecker)(unsafe.Pointer(spellChecker.Native()))
 	C.gspell_entry_buffer_set_spell_checker(e.native(), v1)
@@ -485,8 +715,8 @@ type LanguageChooserButton struct {
 	gtk.Actionable
 }
 
-// wrapLanguageChooserButton wraps the given pointer to *LanguageChooserButton.
-func wrapLanguageChooserButton(ptr unsafe.Pointer) *LanguageChooserButton {
+// WrapLanguageChooserButton wraps the given pointer to *LanguageChooserButton.
+func WrapLanguageChooserButton(ptr unsafe.Pointer) *LanguageChooserButton {
 	obj := glib.Take(ptr)
 	return &LanguageChooserButton{
 		Button: gtk.Button{
@@ -506,19 +736,23 @@ func wrapLanguageChooserButton(ptr unsafe.Pointer) *LanguageChooserButton {
 }
 
 func marshalLanguageChooserButton(p uintptr) (interface{}, error) {
-	return wrapLanguageChooserButton(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapLanguageChooserButton(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
-// LanguageChooserButtonNew creates a new LanguageChooserButton.
+// LanguageChooserButtonNew returns a new [LanguageChooserButton] widget.
+//
+// Parameters:
+//
+//   - currentLanguage: a [Language], or nil to pick the default language.
 func LanguageChooserButtonNew(currentLanguage *Language) *LanguageChooserButton {
 	v1 := (*C.GspellLanguage)(unsafe.Pointer(currentLanguage.Native()))
-	return wrapLanguageChooserButton(unsafe.Pointer(C.gspell_language_chooser_button_new(v1)))
+	return WrapLanguageChooserButton(unsafe.Pointer(C.gspell_language_chooser_button_new(v1)))
 }
 
 // native turns the current *LanguageChooserButton into the native C pointer
 // type.
 func (l *LanguageChooserButton) native() *C.GspellLanguageChooserButton {
-	return (*C.GspellLanguageChooserButton)(gwidget(&l.Button))
+	return (*C.GspellLanguageChooserButton)(gextras.WidgetPtr(&l.Button))
 }
 
 type LanguageChooserDialog struct {
@@ -528,8 +762,8 @@ type LanguageChooserDialog struct {
 	LanguageChooserer
 }
 
-// wrapLanguageChooserDialog wraps the given pointer to *LanguageChooserDialog.
-func wrapLanguageChooserDialog(ptr unsafe.Pointer) *LanguageChooserDialog {
+// WrapLanguageChooserDialog wraps the given pointer to *LanguageChooserDialog.
+func WrapLanguageChooserDialog(ptr unsafe.Pointer) *LanguageChooserDialog {
 	obj := glib.Take(ptr)
 	return &LanguageChooserDialog{
 		Dialog: gtk.Dialog{
@@ -550,22 +784,29 @@ func wrapLanguageChooserDialog(ptr unsafe.Pointer) *LanguageChooserDialog {
 }
 
 func marshalLanguageChooserDialog(p uintptr) (interface{}, error) {
-	return wrapLanguageChooserDialog(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapLanguageChooserDialog(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
-// LanguageChooserDialogNew creates a new LanguageChooserDialog.
+// LanguageChooserDialogNew returns a new [LanguageChooserDialog] widget.
+//
+// Parameters:
+//
+//   - parent: transient parent of the dialog.
+//   - currentLanguage: the [Language] to select initially, or nil to pick the
+//     default language.
+//   - flags: [gtk.DialogFlags]
 func LanguageChooserDialogNew(parent *gtk.Window, currentLanguage *Language, flags gtk.DialogFlags) *LanguageChooserDialog {
 	v1 := (*C.GtkWindow)(unsafe.Pointer(parent.Widget.Native()))
 	v2 := (*C.GspellLanguage)(unsafe.Pointer(currentLanguage.Native()))
 	v3 := C.GtkDialogFlags(flags)
 
-	return wrapLanguageChooserDialog(unsafe.Pointer(C.gspell_language_chooser_dialog_new(v1, v2, v3)))
+	return WrapLanguageChooserDialog(unsafe.Pointer(C.gspell_language_chooser_dialog_new(v1, v2, v3)))
 }
 
 // native turns the current *LanguageChooserDialog into the native C pointer
 // type.
 func (l *LanguageChooserDialog) native() *C.GspellLanguageChooserDialog {
-	return (*C.GspellLanguageChooserDialog)(gwidget(&l.Dialog))
+	return (*C.GspellLanguageChooserDialog)(gextras.WidgetPtr(&l.Dialog))
 }
 
 type NavigatorTextView struct {
@@ -575,8 +816,8 @@ type NavigatorTextView struct {
 	Navigatorer
 }
 
-// wrapNavigatorTextView wraps the given pointer to *NavigatorTextView.
-func wrapNavigatorTextView(ptr unsafe.Pointer) *NavigatorTextView {
+// WrapNavigatorTextView wraps the given pointer to *NavigatorTextView.
+func WrapNavigatorTextView(ptr unsafe.Pointer) *NavigatorTextView {
 	obj := glib.Take(ptr)
 	return &NavigatorTextView{
 		InitiallyUnowned: glib.InitiallyUnowned{
@@ -587,7 +828,7 @@ func wrapNavigatorTextView(ptr unsafe.Pointer) *NavigatorTextView {
 }
 
 func marshalNavigatorTextView(p uintptr) (interface{}, error) {
-	return wrapNavigatorTextView(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapNavigatorTextView(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
 // native turns the current *NavigatorTextView into the native C pointer type.
@@ -595,6 +836,11 @@ func (n *NavigatorTextView) native() *C.GspellNavigatorTextView {
 	return (*C.GspellNavigatorTextView)(unsafe.Pointer(n.InitiallyUnowned.Native()))
 }
 
+// New returns a new [NavigatorTextView] floating object.
+//
+// Parameters:
+//
+//   - view: a [gtk.TextView].
 func New(view *gtk.TextView) *Navigator {
 	v1 := (*C.GtkTextView)(unsafe.Pointer(view.Widget.Native()))
 	obj := glib.Take(unsafe.Pointer(C.gspell_navigator_text_view_new(v1)))
@@ -602,6 +848,7 @@ func New(view *gtk.TextView) *Navigator {
 	return r
 }
 
+// GetView returns the [gtk.TextView].
 func (n *NavigatorTextView) GetView() *gtk.TextView {
 	obj := glib.Take(unsafe.Pointer(C.gspell_navigator_text_view_get_view(n.native())))
 	r := &gtk.TextView{
@@ -620,8 +867,8 @@ type TextBuffer struct {
 	*glib.Object
 }
 
-// wrapTextBuffer wraps the given pointer to *TextBuffer.
-func wrapTextBuffer(ptr unsafe.Pointer) *TextBuffer {
+// WrapTextBuffer wraps the given pointer to *TextBuffer.
+func WrapTextBuffer(ptr unsafe.Pointer) *TextBuffer {
 	obj := glib.Take(ptr)
 	return &TextBuffer{
 		Object: obj,
@@ -629,7 +876,7 @@ func wrapTextBuffer(ptr unsafe.Pointer) *TextBuffer {
 }
 
 func marshalTextBuffer(p uintptr) (interface{}, error) {
-	return wrapTextBuffer(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapTextBuffer(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
 // native turns the current *TextBuffer into the native C pointer type.
@@ -637,14 +884,21 @@ func (t *TextBuffer) native() *C.GspellTextBuffer {
 	return (*C.GspellTextBuffer)(unsafe.Pointer(t.Object.Native()))
 }
 
-// GetFromGtkTextBuffer returns the TextBuffer of gtk_buffer. The returned
-// object is guaranteed to be the same for the lifetime of gtk_buffer.
+// GetFromGtkTextBuffer returns the [TextBuffer] of gtkBuffer. The returned
+// object is guaranteed to be the same for the lifetime of gtkBuffer.
+//
+// Parameters:
+//
+//   - gtkBuffer: a [gtk.TextBuffer].
+//
+// Returns the [TextBuffer] of gtkBuffer.
 func GetFromGtkTextBuffer(gtkBuffer *gtk.TextBuffer) *TextBuffer {
 	v1 := (*C.GtkTextBuffer)(unsafe.Pointer(gtkBuffer.Native()))
-	r := wrapTextBuffer(unsafe.Pointer(C.gspell_text_buffer_get_from_gtk_text_buffer(v1)))
+	r := WrapTextBuffer(unsafe.Pointer(C.gspell_text_buffer_get_from_gtk_text_buffer(v1)))
 	return r
 }
 
+// GetBuffer returns the [gtk.TextBuffer] of gspellBuffer.
 func (t *TextBuffer) GetBuffer() *gtk.TextBuffer {
 	obj := glib.Take(unsafe.Pointer(C.gspell_text_buffer_get_buffer(t.native())))
 	r := &gtk.TextBuffer{
@@ -652,14 +906,20 @@ func (t *TextBuffer) GetBuffer() *gtk.TextBuffer {
 	}
 	return r
 }
+
+// GetSpellChecker returns the [Checker] if one has been set, or nil.
 func (t *TextBuffer) GetSpellChecker() *Checker {
-	r := wrapChecker(unsafe.Pointer(C.gspell_text_buffer_get_spell_checker(t.native())))
+	r := WrapChecker(unsafe.Pointer(C.gspell_text_buffer_get_spell_checker(t.native())))
 	return r
 }
 
-// SetSpellChecker sets a Checker to a TextBuffer. The gspell_buffer will own a
-// reference to spell_checker, so you can release your reference to
-// spell_checker if you no longer need it.
+// SetSpellChecker sets a [Checker] to a [TextBuffer]. The gspellBuffer will own
+// a reference to spellChecker, so you can release your reference to
+// spellChecker if you no longer need it.
+//
+// Parameters:
+//
+//   - spellChecker: a [Checker], or nil to unset the spell checker.
 func (t *TextBuffer) SetSpellChecker(spellChecker *Checker) {
 	v1 := (*C.GspellChecker)(unsafe.Pointer(spellChecker.Native()))
 	C.gspell_text_buffer_set_spell_checker(t.native(), v1)
@@ -669,8 +929,8 @@ type TextView struct {
 	*glib.Object
 }
 
-// wrapTextView wraps the given pointer to *TextView.
-func wrapTextView(ptr unsafe.Pointer) *TextView {
+// WrapTextView wraps the given pointer to *TextView.
+func WrapTextView(ptr unsafe.Pointer) *TextView {
 	obj := glib.Take(ptr)
 	return &TextView{
 		Object: obj,
@@ -678,7 +938,7 @@ func wrapTextView(ptr unsafe.Pointer) *TextView {
 }
 
 func marshalTextView(p uintptr) (interface{}, error) {
-	return wrapTextView(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
+	return WrapTextView(unsafe.Pointer(C.g_value_get_object((*C.GValue)(unsafe.Pointer(p))))), nil
 }
 
 // native turns the current *TextView into the native C pointer type.
@@ -686,53 +946,73 @@ func (t *TextView) native() *C.GspellTextView {
 	return (*C.GspellTextView)(unsafe.Pointer(t.Object.Native()))
 }
 
-// GetFromGtkTextView returns the TextView of gtk_view. The returned object is
-// guaranteed to be the same for the lifetime of gtk_view.
+// GetFromGtkTextView returns the [TextView] of gtkView. The returned object is
+// guaranteed to be the same for the lifetime of gtkView.
+//
+// Parameters:
+//
+//   - gtkView: a [gtk.TextView].
+//
+// Returns the [TextView] of gtkView.
 func GetFromGtkTextView(gtkView *gtk.TextView) *TextView {
 	v1 := (*C.GtkTextView)(unsafe.Pointer(gtkView.Widget.Native()))
-	r := wrapTextView(unsafe.Pointer(C.gspell_text_view_get_from_gtk_text_view(v1)))
+	r := WrapTextView(unsafe.Pointer(C.gspell_text_view_get_from_gtk_text_view(v1)))
 	return r
 }
 
-// BasicSetup function is a convenience function that does the following: - Set
-// a spell checker. The language chosen is the one returned by
-// LanguageGetDefault(). - Set the TextView:inline-spell-checking property to
-// true. - Set the TextView:enable-language-menu property to true.
+// BasicSetup function is a convenience function that does the following:
+//
+//   - Set a spell checker. The language chosen is the one returned by
+//     [LanguageGetDefault].
+//   - Set the [TextView]:inline-spell-checking property to true.
+//   - Set the [TextView]:enable-language-menu property to true.
 //
-// Example: |[ GtkTextView *gtk_view; GspellTextView *gspell_view;
+// Example:
 //
-//    gspell_view = gspell_text_view_get_from_gtk_text_view (gtk_view);
-//    gspell_text_view_basic_setup (gspell_view);
+//	GtkTextView *gtk_view;
+//	GspellTextView *gspell_view;
 //
+//	gspell_view = gspell_text_view_get_from_gtk_text_view (gtk_view);
+//	gspell_text_view_basic_setup (gspell_view);
 //
+// This is equivalent to:
 //
-//    GtkTextView *gtk_view;
-//    GspellTextView *gspell_view;
-//    GspellChecker *checker;
-//    GtkTextBuffer *gtk_buffer;
-//    GspellTextBuffer *gspell_buffer;
+//	GtkTextView *gtk_view;
+//	GspellTextView *gspell_view;
+//	GspellChecker *checker;
+//	GtkTextBuffer *gtk_buffer;
+//	GspellTextBuffer *gspell_buffer;
 //
-//    checker = gspell_checker_new (NULL);
-//    gtk_buffer = gtk_text_view_get_buffer (gtk_view);
-//    gspell_buffer = gspell_text_buffer_get_from_gtk_text_buffer (gtk_buffer);
-//    gspell_text_buffer_set_spell_checker (gspell_buffer, checker);
-//    g_object_unref (checker);
+//	checker = gspell_checker_new (NULL);
+//	gtk_buffer = gtk_text_view_get_buffer (gtk_view);
+//	gspell_buffer = gspell_text_buffer_get_from_gtk_text_buffer (gtk_buffer);
+//	gspell_text_buffer_set_spell_checker (gspell_buffer, checker);
+//	g_object_unref (checker);
 //
-//    gspell_view = gspell_text_view_get_from_gtk_text_view (gtk_view);
-//    gspell_text_view_set_inline_spell_checking (gspell_view, TRUE);
-//    gspell_text_view_set_enable_language_menu (gspell_view, TRUE);
+//	gspell_view = gspell_text_view_get_from_gtk_text_view (gtk_view);
+//	gspell_text_view_set_inline_spell_checking (gspell_view, TRUE);
+//	gspell_text_view_set_enable_language_menu (gspell_view, TRUE);
 //
+// Since: 1.2
 func (t *TextView) BasicSetup() {
 	C.gspell_text_view_basic_setup(t.native())
 }
+
+// GetEnableLanguageMenu returns whether the language context menu is enabled.
+//
+// Since: 1.2
 func (t *TextView) GetEnableLanguageMenu() bool {
-	r := gobool(C.gspell_text_view_get_enable_language_menu(t.native()))
+	r := gextras.Gobool(int(C.gspell_text_view_get_enable_language_menu(t.native())))
 	return r
 }
+
+// GetInlineSpellChecking returns whether the inline spell checking is enabled.
 func (t *TextView) GetInlineSpellChecking() bool {
-	r := gobool(C.gspell_text_view_get_inline_spell_checking(t.native()))
+	r := gextras.Gobool(int(C.gspell_text_view_get_inline_spell_checking(t.native())))
 	return r
 }
+
+// GetView returns the [gtk.TextView] of gspellView.
 func (t *TextView) GetView() *gtk.TextView {
 	obj := glib.Take(unsafe.Pointer(C.gspell_text_view_get_view(t.native())))
 	r := &gtk.TextView{
@@ -748,18 +1028,28 @@ func (t *TextView) GetView() *gtk.TextView {
 }
 
 // SetEnableLanguageMenu sets whether to enable the language context menu. If
-// enabled, doing a right click on the TextView will show a sub-menu to choose
-// the language for the spell checking. If another language is chosen, it
-// changes the Checker:language property of the TextBuffer:spell-checker of the
-// TextView:buffer of the TextView:view.
+// enabled, doing a right click on the [gtk.TextView] will show a sub-menu to
+// choose the language for the spell checking. If another language is chosen, it
+// changes the [Checker]:language property of the [TextBuffer]:spell-checker of
+// the [gtk.TextView]:buffer of the [TextView]:view.
+//
+// Parameters:
+//
+//   - enableLanguageMenu: whether to enable the language context menu.
+//
+// Since: 1.2
 func (t *TextView) SetEnableLanguageMenu(enableLanguageMenu bool) {
-	v1 := cbool(enableLanguageMenu)
+	v1 := C.gboolean(gextras.Cbool(enableLanguageMenu))
 	C.gspell_text_view_set_enable_language_menu(t.native(), v1)
 }
 
 // SetInlineSpellChecking enables or disables the inline spell checking.
+//
+// Parameters:
+//
+//   - enable: the new state.
 func (t *TextView) SetInlineSpellChecking(enable bool) {
-	v1 := cbool(enable)
+	v1 := C.gboolean(gextras.Cbool(enable))
 	C.gspell_text_view_set_inline_spell_checking(t.native(), v1)
 }
 
@@ -779,7 +1069,14 @@ func (l *Language) Native() uintptr {
 }
 
 // Compare compares alphabetically two languages by their name, as returned by
-// C.gspell_language_get_name().
+// [Language.GetName].
+//
+// Parameters:
+//
+//   - languageB: another [Language].
+//
+// Returns an integer less than, equal to, or greater than zero, if languageA is
+// <, == or > than languageB.
 func (l *Language) Compare(languageB *Language) int {
 	v1 := (*C.GspellLanguage)(unsafe.Pointer(languageB.Native()))
 	r := int(C.gspell_language_compare(l.native(), v1))
@@ -787,6 +1084,8 @@ func (l *Language) Compare(languageB *Language) int {
 }
 
 // Copy used by language bindings.
+//
+// Returns a copy of lang. The caller owns the returned value.
 func (l *Language) Copy() *Language {
 	r := (*Language)(C.gspell_language_copy(l.native()))
 	return r
@@ -796,6 +1095,8 @@ func (l *Language) Copy() *Language {
 func (l *Language) Free() {
 	C.gspell_language_free(l.native())
 }
+
+// GetCode returns the language code, for example fr_BE.
 func (l *Language) GetCode() string {
 	r := C.GoString(C.gspell_language_get_code(l.native()))
 	return r
@@ -804,6 +1105,8 @@ func (l *Language) GetCode() string {
 // GetName returns the language name translated to the current locale. For
 // example "French (Belgium)" is returned if the current locale is in English
 // and the language code is fr_BE.
+//
+// Returns the language name.
 func (l *Language) GetName() string {
 	r := C.GoString(C.gspell_language_get_name(l.native()))
 	return r
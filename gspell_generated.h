@@ -0,0 +1,22 @@
+// Code generated by girgen. DO NOT EDIT.
+
+#ifndef GSPELL_GENERATED_H
+#define GSPELL_GENERATED_H
+
+#include <stdint.h>
+#include <gspell/gspell.h>
+#include <gtk/gtk.h>
+#include <atk/atk.h>
+#include <glib-object.h>
+#include <glib.h>
+#include <gdk/gdk.h>
+#include <cairo-gobject.h>
+#include <pango/pango.h>
+#include <gdk-pixbuf/gdk-pixbuf.h>
+#include <gio/gio.h>
+
+// Deletes the callback in the registry that the user data points to. It is
+// given to C as a GDestroyNotify.
+void gspell_trampoline_delete(gpointer data);
+
+#endif // GSPELL_GENERATED_H
@@ -0,0 +1,458 @@
+package gir
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Impact is what an API change means for the generated Go package.
+type Impact string
+
+const (
+	// ImpactBreaking changes break code using the generated package.
+	ImpactBreaking Impact = "breaking"
+	// ImpactAdditive changes only add to the generated package.
+	ImpactAdditive Impact = "additive"
+	// ImpactNone changes don't affect the generated package, such as changes
+	// to symbols that aren't generated.
+	ImpactNone Impact = "none"
+)
+
+// APIChange is a single change between two versions of a namespace.
+type APIChange struct {
+	// Change is either "added", "removed" or "changed".
+	Change string `json:"change"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	GoName string `json:"go_name,omitempty"`
+	Impact Impact `json:"impact"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// APIDiff is the list of changes between two versions of a namespace.
+type APIDiff struct {
+	Old      string      `json:"old"`
+	New      string      `json:"new"`
+	Breaking int         `json:"breaking"`
+	Additive int         `json:"additive"`
+	Changes  []APIChange `json:"changes"`
+}
+
+// DiffDumps compares the dumps of two versions of a namespace.
+func DiffDumps(old, new Dump) APIDiff {
+	var d = APIDiff{
+		Old:     old.Namespace + "-" + old.Version,
+		New:     new.Namespace + "-" + new.Version,
+		Changes: []APIChange{},
+	}
+
+	d.diffObjects("class", old.Classes, new.Classes)
+	d.diffObjects("interface", old.Interfaces, new.Interfaces)
+	d.diffObjects("record", old.Records, new.Records)
+	d.diffEnums(old.Enums, new.Enums)
+	d.diffCallables("function", "", old.Functions, new.Functions)
+	d.diffCallables("callback", "", old.Callbacks, new.Callbacks)
+
+	for _, change := range d.Changes {
+		switch change.Impact {
+		case ImpactBreaking:
+			d.Breaking++
+		case ImpactAdditive:
+			d.Additive++
+		}
+	}
+
+	return d
+}
+
+func (d *APIDiff) add(change APIChange) {
+	d.Changes = append(d.Changes, change)
+}
+
+// addOrRemove adds an added or removed change, whose impact depends on whether
+// the symbol is generated.
+func (d *APIDiff) addOrRemove(added bool, kind, name, goName string, generated bool) {
+	var change = APIChange{Kind: kind, Name: name, GoName: goName, Impact: ImpactNone}
+
+	if added {
+		change.Change = "added"
+		if generated {
+			change.Impact = ImpactAdditive
+		}
+	} else {
+		change.Change = "removed"
+		if generated {
+			change.Impact = ImpactBreaking
+		}
+	}
+
+	if !generated {
+		change.Detail = "not generated"
+	}
+
+	d.add(change)
+}
+
+func (d *APIDiff) diffObjects(kind string, old, new []DumpObject) {
+	var oldObjs = make(map[string]DumpObject, len(old))
+	for _, obj := range old {
+		oldObjs[obj.Name] = obj
+	}
+
+	var newObjs = make(map[string]DumpObject, len(new))
+	for _, obj := range new {
+		newObjs[obj.Name] = obj
+	}
+
+	for _, obj := range old {
+		if _, ok := newObjs[obj.Name]; !ok {
+			d.addOrRemove(false, kind, obj.Name, obj.GoName, !obj.Ignored)
+		}
+	}
+
+	for _, newObj := range new {
+		oldObj, ok := oldObjs[newObj.Name]
+		if !ok {
+			d.addOrRemove(true, kind, newObj.Name, newObj.GoName, !newObj.Ignored)
+			continue
+		}
+
+		d.diffObject(kind, oldObj, newObj)
+	}
+}
+
+func (d *APIDiff) diffObject(kind string, old, new DumpObject) {
+	var change = APIChange{
+		Change: "changed",
+		Kind:   kind,
+		Name:   new.Name,
+		GoName: new.GoName,
+	}
+
+	switch {
+	case old.Ignored && !new.Ignored:
+		change.Impact = ImpactAdditive
+		change.Detail = "now generated"
+		d.add(change)
+	case !old.Ignored && new.Ignored:
+		change.Impact = ImpactBreaking
+		change.Detail = "no longer generated: " + new.IgnoreReason
+		d.add(change)
+	}
+
+	if old.Parent != new.Parent {
+		change.Impact = ImpactBreaking
+		change.Detail = fmt.Sprintf("parent changed from %s to %s", old.Parent, new.Parent)
+		d.add(change)
+	}
+
+	for _, name := range missing(old.Implements, new.Implements) {
+		change.Impact = ImpactBreaking
+		change.Detail = "no longer implements " + name
+		d.add(change)
+	}
+
+	for _, name := range missing(new.Implements, old.Implements) {
+		change.Impact = ImpactAdditive
+		change.Detail = "now implements " + name
+		d.add(change)
+	}
+
+	d.diffCallables("constructor", new.Name, old.Constructors, new.Constructors)
+	d.diffCallables("method", new.Name, old.Methods, new.Methods)
+	d.diffCallables("function", new.Name, old.Functions, new.Functions)
+	d.diffProperties(new.Name, old.Properties, new.Properties)
+	d.diffSignals(new.Name, old.Signals, new.Signals)
+}
+
+// missing returns the strings in a that aren't in b.
+func missing(a, b []string) []string {
+	var missing []string
+
+outer:
+	for _, str := range a {
+		for _, other := range b {
+			if str == other {
+				continue outer
+			}
+		}
+		missing = append(missing, str)
+	}
+
+	return missing
+}
+
+func (d *APIDiff) diffCallables(kind, parent string, old, new []DumpCallable) {
+	var prefix string
+	if parent != "" {
+		prefix = parent + "."
+	}
+
+	var oldFns = make(map[string]DumpCallable, len(old))
+	for _, fn := range old {
+		oldFns[fn.Name] = fn
+	}
+
+	var newFns = make(map[string]DumpCallable, len(new))
+	for _, fn := range new {
+		newFns[fn.Name] = fn
+	}
+
+	for _, fn := range old {
+		if _, ok := newFns[fn.Name]; !ok {
+			d.addOrRemove(false, kind, prefix+fn.Name, fn.GoName, !fn.Ignored)
+		}
+	}
+
+	for _, newFn := range new {
+		oldFn, ok := oldFns[newFn.Name]
+		if !ok {
+			d.addOrRemove(true, kind, prefix+newFn.Name, newFn.GoName, !newFn.Ignored)
+			continue
+		}
+
+		var change = APIChange{
+			Change: "changed",
+			Kind:   kind,
+			Name:   prefix + newFn.Name,
+			GoName: newFn.GoName,
+		}
+
+		oldSig, newSig := oldFn.goSignature(), newFn.goSignature()
+
+		switch {
+		case oldFn.Ignored && newFn.Ignored:
+			if oldSig != newSig {
+				change.Impact = ImpactNone
+				change.Detail = "signature changed; not generated"
+				d.add(change)
+			}
+		case oldFn.Ignored:
+			change.Impact = ImpactAdditive
+			change.Detail = "now generated"
+			d.add(change)
+		case newFn.Ignored:
+			change.Impact = ImpactBreaking
+			change.Detail = "no longer generated: " + newFn.IgnoreReason
+			d.add(change)
+		case oldSig != newSig:
+			change.Impact = ImpactBreaking
+			change.Detail = fmt.Sprintf("signature changed from %s to %s", oldSig, newSig)
+			d.add(change)
+		}
+	}
+}
+
+// goSignature returns the parameter and return types of the generated Go
+// function. Parameter names are left out, since renaming them doesn't break
+// anything.
+func (c DumpCallable) goSignature() string {
+	var params []string
+	for _, param := range c.Parameters {
+		if !param.Ignored {
+			params = append(params, param.Type.GoType)
+		}
+	}
+
+	var sig = "(" + strings.Join(params, ", ") + ")"
+	if c.Returns != nil && c.Returns.Type.GoType != "" {
+		sig += " " + c.Returns.Type.GoType
+	}
+
+	return sig
+}
+
+func (d *APIDiff) diffProperties(parent string, old, new []DumpProperty) {
+	var oldProps = make(map[string]DumpProperty, len(old))
+	for _, prop := range old {
+		oldProps[prop.Name] = prop
+	}
+
+	var newProps = make(map[string]DumpProperty, len(new))
+	for _, prop := range new {
+		newProps[prop.Name] = prop
+	}
+
+	// Properties aren't generated, so none of their changes have an impact.
+	for _, prop := range old {
+		if _, ok := newProps[prop.Name]; !ok {
+			d.addOrRemove(false, "property", parent+":"+prop.Name, "", false)
+		}
+	}
+
+	for _, newProp := range new {
+		oldProp, ok := oldProps[newProp.Name]
+		if !ok {
+			d.addOrRemove(true, "property", parent+":"+newProp.Name, "", false)
+			continue
+		}
+
+		var details []string
+		if oldProp.Type != newProp.Type {
+			details = append(details,
+				fmt.Sprintf("type changed from %s to %s", oldProp.Type.Name, newProp.Type.Name))
+		}
+		if oldProp.Readable != newProp.Readable || oldProp.Writable != newProp.Writable {
+			details = append(details, "access changed")
+		}
+
+		if len(details) > 0 {
+			d.add(APIChange{
+				Change: "changed",
+				Kind:   "property",
+				Name:   parent + ":" + newProp.Name,
+				Impact: ImpactNone,
+				Detail: strings.Join(details, "; ") + "; not generated",
+			})
+		}
+	}
+}
+
+func (d *APIDiff) diffSignals(parent string, old, new []DumpSignal) {
+	var oldSignals = make(map[string]DumpSignal, len(old))
+	for _, signal := range old {
+		oldSignals[signal.Name] = signal
+	}
+
+	var newSignals = make(map[string]DumpSignal, len(new))
+	for _, signal := range new {
+		newSignals[signal.Name] = signal
+	}
+
+	// Signals aren't generated either.
+	for _, signal := range old {
+		if _, ok := newSignals[signal.Name]; !ok {
+			d.addOrRemove(false, "signal", parent+"::"+signal.Name, "", false)
+		}
+	}
+
+	for _, newSignal := range new {
+		oldSignal, ok := oldSignals[newSignal.Name]
+		if !ok {
+			d.addOrRemove(true, "signal", parent+"::"+newSignal.Name, "", false)
+			continue
+		}
+
+		oldSig := DumpCallable{Parameters: oldSignal.Parameters, Returns: oldSignal.Returns}
+		newSig := DumpCallable{Parameters: newSignal.Parameters, Returns: newSignal.Returns}
+
+		if oldSig.goSignature() != newSig.goSignature() {
+			d.add(APIChange{
+				Change: "changed",
+				Kind:   "signal",
+				Name:   parent + "::" + newSignal.Name,
+				Impact: ImpactNone,
+				Detail: "signature changed; not generated",
+			})
+		}
+	}
+}
+
+func (d *APIDiff) diffEnums(old, new []DumpEnum) {
+	var oldEnums = make(map[string]DumpEnum, len(old))
+	for _, enum := range old {
+		oldEnums[enum.Name] = enum
+	}
+
+	var newEnums = make(map[string]DumpEnum, len(new))
+	for _, enum := range new {
+		newEnums[enum.Name] = enum
+	}
+
+	for _, enum := range old {
+		if _, ok := newEnums[enum.Name]; !ok {
+			d.addOrRemove(false, enumKind(enum), enum.Name, enum.GoName, true)
+		}
+	}
+
+	for _, newEnum := range new {
+		oldEnum, ok := oldEnums[newEnum.Name]
+		if !ok {
+			d.addOrRemove(true, enumKind(newEnum), newEnum.Name, newEnum.GoName, true)
+			continue
+		}
+
+		if oldEnum.Flags != newEnum.Flags {
+			d.add(APIChange{
+				Change: "changed",
+				Kind:   enumKind(newEnum),
+				Name:   newEnum.Name,
+				GoName: newEnum.GoName,
+				Impact: ImpactBreaking,
+				Detail: "changed from " + enumKind(oldEnum) + " to " + enumKind(newEnum),
+			})
+		}
+
+		d.diffMembers(newEnum.Name, oldEnum.Members, newEnum.Members)
+	}
+}
+
+func enumKind(enum DumpEnum) string {
+	if enum.Flags {
+		return "bitfield"
+	}
+	return "enum"
+}
+
+func (d *APIDiff) diffMembers(parent string, old, new []DumpMember) {
+	var oldMembers = make(map[string]DumpMember, len(old))
+	for _, member := range old {
+		oldMembers[member.Name] = member
+	}
+
+	var newMembers = make(map[string]DumpMember, len(new))
+	for _, member := range new {
+		newMembers[member.Name] = member
+	}
+
+	for _, member := range old {
+		if _, ok := newMembers[member.Name]; !ok {
+			d.addOrRemove(false, "member", parent+"."+member.Name, member.GoName, true)
+		}
+	}
+
+	for _, newMember := range new {
+		oldMember, ok := oldMembers[newMember.Name]
+		if !ok {
+			d.addOrRemove(true, "member", parent+"."+newMember.Name, newMember.GoName, true)
+			continue
+		}
+
+		if oldMember.Value != newMember.Value {
+			d.add(APIChange{
+				Change: "changed",
+				Kind:   "member",
+				Name:   parent + "." + newMember.Name,
+				GoName: newMember.GoName,
+				Impact: ImpactBreaking,
+				Detail: fmt.Sprintf("value changed from %d to %d", oldMember.Value, newMember.Value),
+			})
+		}
+	}
+}
+
+// WriteText writes the diff in a human-readable table.
+func (d APIDiff) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "%s -> %s: %d breaking, %d additive, %d total change(s)\n",
+		d.Old, d.New, d.Breaking, d.Additive, len(d.Changes))
+
+	if len(d.Changes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for _, change := range d.Changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s", change.Impact, change.Change, change.Kind, change.Name)
+		if change.Detail != "" {
+			fmt.Fprintf(tw, "\t%s", change.Detail)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,123 @@
+package gir
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDumps(t *testing.T) {
+	stringType := DumpType{Name: "utf8", GoType: "string"}
+	intType := DumpType{Name: "gint", GoType: "int"}
+
+	callable := func(name string, params ...DumpType) DumpCallable {
+		fn := DumpCallable{Name: name, GoName: name}
+		for _, param := range params {
+			fn.Parameters = append(fn.Parameters, DumpParameter{Type: param})
+		}
+		return fn
+	}
+
+	tests := []struct {
+		name     string
+		old, new Dump
+		want     []APIChange
+	}{{
+		name: "unchanged",
+		old:  Dump{Functions: []DumpCallable{callable("f", stringType)}},
+		new:  Dump{Functions: []DumpCallable{callable("f", stringType)}},
+		want: []APIChange{},
+	}, {
+		name: "added",
+		old:  Dump{},
+		new: Dump{
+			Functions: []DumpCallable{callable("f")},
+			Classes:   []DumpObject{{Name: "Hidden", GoName: "Hidden", Ignored: true}},
+		},
+		want: []APIChange{
+			{Change: "added", Kind: "class", Name: "Hidden", GoName: "Hidden", Impact: ImpactNone, Detail: "not generated"},
+			{Change: "added", Kind: "function", Name: "f", GoName: "f", Impact: ImpactAdditive},
+		},
+	}, {
+		name: "removed",
+		old: Dump{
+			Classes: []DumpObject{{
+				Name:       "Checker",
+				GoName:     "Checker",
+				Methods:    []DumpCallable{callable("check_word")},
+				Properties: []DumpProperty{{Name: "language"}},
+			}},
+			Enums: []DumpEnum{{Name: "Error", GoName: "Error"}},
+		},
+		new: Dump{
+			Classes: []DumpObject{{Name: "Checker", GoName: "Checker"}},
+		},
+		want: []APIChange{
+			{Change: "removed", Kind: "method", Name: "Checker.check_word", GoName: "check_word", Impact: ImpactBreaking},
+			{Change: "removed", Kind: "property", Name: "Checker:language", Impact: ImpactNone, Detail: "not generated"},
+			{Change: "removed", Kind: "enum", Name: "Error", GoName: "Error", Impact: ImpactBreaking},
+		},
+	}, {
+		name: "changed",
+		old: Dump{
+			Classes: []DumpObject{{Name: "Checker", GoName: "Checker", Parent: "GObject.Object"}},
+			Enums: []DumpEnum{{
+				Name:    "Error",
+				GoName:  "Error",
+				Members: []DumpMember{{Name: "dictionary", GoName: "ErrorDictionary", Value: 0}},
+			}},
+			Functions: []DumpCallable{
+				callable("f", stringType),
+				{Name: "g", GoName: "g", Ignored: true},
+			},
+		},
+		new: Dump{
+			Classes: []DumpObject{{Name: "Checker", GoName: "Checker", Parent: "GObject.InitiallyUnowned"}},
+			Enums: []DumpEnum{{
+				Name:    "Error",
+				GoName:  "Error",
+				Flags:   true,
+				Members: []DumpMember{{Name: "dictionary", GoName: "ErrorDictionary", Value: 1}},
+			}},
+			Functions: []DumpCallable{
+				callable("f", intType),
+				{Name: "g", GoName: "g"},
+			},
+		},
+		want: []APIChange{
+			{Change: "changed", Kind: "class", Name: "Checker", GoName: "Checker", Impact: ImpactBreaking,
+				Detail: "parent changed from GObject.Object to GObject.InitiallyUnowned"},
+			{Change: "changed", Kind: "bitfield", Name: "Error", GoName: "Error", Impact: ImpactBreaking,
+				Detail: "changed from enum to bitfield"},
+			{Change: "changed", Kind: "member", Name: "Error.dictionary", GoName: "ErrorDictionary", Impact: ImpactBreaking,
+				Detail: "value changed from 0 to 1"},
+			{Change: "changed", Kind: "function", Name: "f", GoName: "f", Impact: ImpactBreaking,
+				Detail: "signature changed from (string) to (int)"},
+			{Change: "changed", Kind: "function", Name: "g", GoName: "g", Impact: ImpactAdditive,
+				Detail: "now generated"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DiffDumps(test.old, test.new)
+			if !reflect.DeepEqual(got.Changes, test.want) {
+				t.Errorf("got  %+v\nwant %+v", got.Changes, test.want)
+			}
+
+			var breaking, additive int
+			for _, change := range test.want {
+				switch change.Impact {
+				case ImpactBreaking:
+					breaking++
+				case ImpactAdditive:
+					additive++
+				}
+			}
+
+			if got.Breaking != breaking || got.Additive != additive {
+				t.Errorf("got %d breaking and %d additive, want %d and %d",
+					got.Breaking, got.Additive, breaking, additive)
+			}
+		})
+	}
+}
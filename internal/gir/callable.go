@@ -12,6 +12,7 @@ type CallableAttrs struct {
 	CType       string `xml:"http://www.gtk.org/introspection/c/1.0 type,attr"`
 	CIdentifier string `xml:"http://www.gtk.org/introspection/c/1.0 identifier,attr"`
 	Throws      bool   `xml:"throws,attr"`
+	Version     string `xml:"version,attr"`
 
 	Parameters  *Parameters
 	ReturnValue *ReturnValue `xml:"http://www.gtk.org/introspection/core/1.0 return-value"`
@@ -33,6 +34,36 @@ func (c CallableAttrs) UserDataParameter(i int) *Parameter {
 	return nil
 }
 
+// HasDestroyNotify returns true if the callable takes a GDestroyNotify, which
+// C calls once it's done with the user data.
+func (c CallableAttrs) HasDestroyNotify() bool {
+	if c.Parameters == nil {
+		return false
+	}
+
+	for _, param := range c.Parameters.Parameters {
+		if param.IsDestroyNotifyFunc() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CallbackScope returns the scope of the callback parameter. Without a scope
+// attribute, it's "notified" if the callable takes a GDestroyNotify, and "call"
+// otherwise, like gobject-introspection assumes.
+func (c CallableAttrs) CallbackScope(callback Parameter) string {
+	switch {
+	case callback.Scope != "":
+		return callback.Scope
+	case c.HasDestroyNotify():
+		return "notified"
+	default:
+		return "call"
+	}
+}
+
 func (c CallableAttrs) HasInstanceParameter() bool {
 	return c.Parameters != nil && c.Parameters.HasInstanceParameter()
 }
@@ -78,22 +109,70 @@ func (c CallableAttrs) HasMultiPtr() bool {
 	return false
 }
 
-var ignoredCallables = []func(CallableAttrs) bool{
-	CallableAttrs.IsCopyFn,
-	CallableAttrs.IsBlocked,
-	CallableAttrs.IsVariadic,
-	CallableAttrs.HasArrayParameter, // TODO support arrays
-	CallableAttrs.HasMultiPtr,       // TODO support output pointer
+var ignoredCallables = []struct {
+	Reason    string
+	IsIgnored func(CallableAttrs) bool
+}{
+	{"copy function", CallableAttrs.IsCopyFn},
+	{"blocked type", CallableAttrs.IsBlocked},
+	{"variadic", CallableAttrs.IsVariadic},
+	{"array", CallableAttrs.HasArrayParameter},   // TODO support arrays
+	{"multi-pointer", CallableAttrs.HasMultiPtr}, // TODO support output pointer
 }
 
 func (c CallableAttrs) IsIgnored() bool {
-	for _, isIgnored := range ignoredCallables {
-		if isIgnored(c) {
-			return true
+	return c.IgnoreReason() != ""
+}
+
+// IgnoreReason returns the reason the callable is not generated, or an empty
+// string if it is.
+func (c CallableAttrs) IgnoreReason() string {
+	for _, ignored := range ignoredCallables {
+		if ignored.IsIgnored(c) {
+			return ignored.Reason
 		}
 	}
 
-	return false
+	return ""
+}
+
+// GenGoDoc generates the documentation of the callable, followed by the
+// documentation of its parameters, its return value and the version it was
+// added in. Nil is returned if there's nothing to document.
+func (c CallableAttrs) GenGoDoc(indentLvl uint, selfName, prefix string) *jen.Statement {
+	var blocks []docBlock
+	var returns = c.ReturnValue.docString()
+
+	switch {
+	case c.Doc != nil && c.Doc.String != "":
+		blocks = parseGtkDoc(strings.TrimPrefix(c.Doc.Lower(), "this "))
+	case returns != "":
+		// Use the return value as the summary if there's no documentation.
+		blocks = parseGtkDoc("returns " + returns)
+		returns = ""
+	default:
+		return nil
+	}
+
+	if params := c.Parameters.docItems(); len(params) > 0 {
+		blocks = append(blocks,
+			docBlock{Kind: docParagraph, Lines: []string{"Parameters:"}},
+			docBlock{Kind: docList, Lines: params},
+		)
+	}
+
+	if returns != "" {
+		blocks = append(blocks, docBlock{
+			Kind:  docParagraph,
+			Lines: []string{"Returns " + returns},
+		})
+	}
+
+	if c.Version != "" {
+		blocks = append(blocks, sinceBlock(c.Version))
+	}
+
+	return genDocBlocksIndent(indentLvl, selfName, prefix, blocks)
 }
 
 type Parameters struct {
@@ -146,6 +225,44 @@ func (p *Parameters) SearchUserData() *Parameter {
 	return nil
 }
 
+// docItems returns the list items documenting each parameter that is visible
+// in the Go function signature.
+func (p *Parameters) docItems() []string {
+	if p == nil {
+		return nil
+	}
+
+	var items []string
+
+	for _, param := range p.Parameters {
+		if param.IsIgnored() {
+			continue
+		}
+
+		var doc string
+		if param.Doc != nil {
+			doc = param.Doc.String
+		}
+
+		if param.IsNullable() && !strings.Contains(doc, "%NULL") {
+			doc = strings.TrimSpace(doc + " It may be %NULL.")
+		}
+
+		if param.IsTransferFull() && param.Type.IsPtr() {
+			doc = strings.TrimSpace(doc + " Ownership is transferred to the callee.")
+		}
+
+		if doc == "" {
+			continue
+		}
+
+		doc = gtkdocEntities.Replace(strings.Join(strings.Fields(doc), " "))
+		items = append(items, param.GoName()+": "+doc)
+	}
+
+	return items
+}
+
 type InstanceParameter struct {
 	XMLName xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 instance-parameter"`
 	ParameterAttrs
@@ -159,6 +276,10 @@ type Parameter struct {
 type ParameterAttrs struct {
 	Name      string `xml:"name,attr"`
 	AllowNone int    `xml:"allow-none,attr"` // 1 == true?
+	Nullable  int    `xml:"nullable,attr"`
+	// Scope is how long C keeps a callback: "call", "async", "notified" or
+	// "forever". Use CallableAttrs.CallbackScope instead.
+	Scope string `xml:"scope,attr"`
 	TransferOwnership
 	Type Type
 	Doc  *Doc
@@ -175,6 +296,11 @@ func (p ParameterAttrs) IsVariadic() bool {
 	return p.Name == "..."
 }
 
+// IsNullable returns true if the parameter may be nil.
+func (p ParameterAttrs) IsNullable() bool {
+	return p.Nullable == 1 || p.AllowNone == 1
+}
+
 var ignoredParams = []func(ParameterAttrs) bool{
 	ParameterAttrs.IsVariadic,
 	ParameterAttrs.IsUserData,
@@ -250,7 +376,8 @@ func (p ParameterAttrs) GenValueCall(argName, valueName *jen.Statement) *jen.Sta
 }
 
 type ReturnValue struct {
-	XMLName xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 return-value"`
+	XMLName  xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 return-value"`
+	Nullable int      `xml:"nullable,attr"`
 	TransferOwnership
 	Doc *Doc
 
@@ -269,6 +396,11 @@ type Array struct {
 	Type Type `xml:"http://www.gtk.org/introspection/core/1.0 type"`
 }
 
+// IsNullable returns true if the return value may be nil.
+func (r *ReturnValue) IsNullable() bool {
+	return r != nil && r.Nullable == 1
+}
+
 // IsVoid returns true if the type name is "none" or if *ReturnValue is nil.
 func (r *ReturnValue) IsVoid() bool {
 	if r == nil {
@@ -278,6 +410,27 @@ func (r *ReturnValue) IsVoid() bool {
 	return r.Type.Name == "none"
 }
 
+// docString returns the documentation of the return value, including notes on
+// its nullability and ownership. An empty string is returned if there's
+// nothing to document.
+func (r *ReturnValue) docString() string {
+	if r.IsVoid() || r.Doc == nil {
+		return ""
+	}
+
+	var doc = r.Doc.Lower()
+
+	if r.IsNullable() && !strings.Contains(doc, "%NULL") {
+		doc += " The returned value may be %NULL."
+	}
+
+	if r.IsTransferFull() && r.Type.IsPtr() {
+		doc += " The caller owns the returned value."
+	}
+
+	return gtkdocEntities.Replace(strings.Join(strings.Fields(doc), " "))
+}
+
 // GenReturn generates a statement with the return token.
 func (r *ReturnValue) GenReturnFunc(call *jen.Statement) *jen.Statement {
 	if r.IsVoid() {
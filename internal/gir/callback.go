@@ -19,9 +19,7 @@ func (c Callback) GoName() string {
 
 func (c Callback) GenGoType() *jen.Statement {
 	var s = new(jen.Statement)
-	if c.Doc != nil {
-		s.Add(c.Doc.GenGoComments("", c.GoName()))
-	}
+	s.Add(c.GenGoDoc(0, "", c.GoName()))
 
 	s.Type().Id(c.GoName()).Func()
 
@@ -48,11 +46,12 @@ func (c Callback) GenGoType() *jen.Statement {
 
 // GenGlobalGoFunction generates a Go function with the export comment. This
 // function is used to be called from C. It triggers the callback inside the
-// map.
+// map. Panics are recovered and given to the callback package's panic handler,
+// in which case the C zero value is returned.
 func (c Callback) GenGlobalGoFunction() *jen.Statement {
-	s := jen.Comment("//export callback" + c.Name)
+	s := jen.Comment("//export " + c.ExternCName())
 	s.Line()
-	s.Func().Id("callback" + c.Name)
+	s.Func().Id(c.ExternCName())
 
 	s.ParamsFunc(func(g *jen.Group) {
 		if c.Parameters == nil {
@@ -60,6 +59,12 @@ func (c Callback) GenGlobalGoFunction() *jen.Statement {
 		}
 
 		for _, param := range c.Parameters.Parameters {
+			// The trampoline gives the user data as the integer handle.
+			if param.IsUserData() {
+				g.Add(jen.Id(param.GoName()), jen.Qual("C", "uintptr_t"))
+				continue
+			}
+
 			g.Add(jen.Id(param.GoName()), param.Type.GenCGoType())
 		}
 	})
@@ -80,13 +85,15 @@ func (c Callback) GenGlobalGoFunction() *jen.Statement {
 			return
 		}
 
-		g.Id("fn").Op(":=").Qual("github.com/diamondburned/gspell/internal/callback", "Get").Call(
-			jen.Uintptr().Call(jen.Id(userData.GoName())),
+		g.Defer().Qual(CallbackImportPath(), "Recover").Call(jen.Lit(c.Name))
+		g.Line()
+
+		g.Id("fn").Op(":=").Qual(CallbackImportPath(), "Get").Call(
+			jen.Qual(CallbackImportPath(), "Handle").Call(jen.Id(userData.GoName())),
 		)
 
-		// TODO: is this panic worthy?
 		g.If(jen.Id("fn").Op("==").Nil()).Block(
-			jen.Panic(jen.Lit(fmt.Sprintf("callback for %s not found", c.Name))),
+			jen.Panic(jen.Qual(CallbackImportPath(), "ErrNotFound")),
 		)
 
 		g.Line()
@@ -148,50 +155,175 @@ func (c Callback) ExternCName() string {
 	return CallbackExternCName(c.Name)
 }
 
+// CallbackExternCName returns the name of the exported Go function of the
+// callback. It has the C symbol prefix of the namespace, so that the exports of
+// different generated packages don't clash when linked together.
 func CallbackExternCName(callbackName string) string {
-	return fmt.Sprintf("callback%s", callbackName)
+	return cSymbolPrefix() + "_callback" + callbackName
+}
+
+// CallbackDeleteExternCName returns the name of the exported Go function that
+// the delete trampoline calls.
+func CallbackDeleteExternCName() string {
+	return cSymbolPrefix() + "_callbackDelete"
+}
+
+func (c Callback) TrampolineCName() string {
+	return CallbackTrampolineCName(c.Name)
+}
+
+// CallbackTrampolineCName returns the name of the C trampoline that calls the
+// exported Go function of the callback. The trampoline is what's given to C.
+func CallbackTrampolineCName(callbackName string) string {
+	return cSymbolPrefix() + "_trampoline_" + callbackName
+}
+
+// CallbackDeleteTrampolineCName returns the name of the C trampoline that
+// deletes callbacks from the registry when C destroys their user data.
+func CallbackDeleteTrampolineCName() string {
+	return cSymbolPrefix() + "_trampoline_delete"
 }
 
-func (c Callback) GenExternC() string {
-	s := strings.Builder{}
-	s.WriteString("extern ")
-	s.WriteString(c.ReturnValue.Type.CType)
-	s.WriteString(" ")
-	s.WriteString(c.ExternCName())
+// cSymbolPrefix returns the first C symbol prefix of the active namespace.
+func cSymbolPrefix() string {
+	return strings.Split(activeNamespace.SymbolPrefixes, ",")[0]
+}
 
-	s.WriteString("(")
+// GenTrampolineDecl generates the C declaration of the trampoline.
+func (c Callback) GenTrampolineDecl() string {
+	var returnType = "void"
+	if !c.ReturnValue.IsVoid() {
+		returnType = c.ReturnValue.Type.CType
+	}
 
+	var params []string
 	if c.Parameters != nil {
-		var params = make([]string, len(c.Parameters.Parameters))
 		for i, param := range c.Parameters.Parameters {
-			params[i] = fmt.Sprintf("%s v%d", param.Type.CType, i)
+			params = append(params, fmt.Sprintf("%s v%d", param.Type.CType, i))
 		}
-		s.WriteString(strings.Join(params, ", "))
 	}
 
-	s.WriteString(");")
+	if len(params) == 0 {
+		params = []string{"void"}
+	}
 
-	return s.String()
+	return fmt.Sprintf("%s %s(%s)", returnType, c.TrampolineCName(), strings.Join(params, ", "))
 }
 
-// TODO: gen assign fn
+// GenTrampoline generates the C definition of the trampoline, which calls the
+// exported Go function.
+func (c Callback) GenTrampoline() string {
+	var args []string
+	if c.Parameters != nil {
+		for i, param := range c.Parameters.Parameters {
+			var arg = fmt.Sprintf("v%d", i)
+
+			// cgo drops const in exported functions.
+			switch {
+			case param.IsUserData():
+				arg = "(uintptr_t)" + arg
+			case param.Type.IsConst():
+				ctype := strings.TrimSpace(strings.TrimPrefix(param.Type.CType, "const"))
+				arg = fmt.Sprintf("(%s)%s", ctype, arg)
+			}
+
+			args = append(args, arg)
+		}
+	}
+
+	var call = fmt.Sprintf("%s(%s);", c.ExternCName(), strings.Join(args, ", "))
+	if !c.ReturnValue.IsVoid() {
+		call = "return " + call
+	}
+
+	return fmt.Sprintf("%s {\n\t%s\n}\n", c.GenTrampolineDecl(), call)
+}
 
 // GenCGoFunc generates the CGo function to be used in the arguments.
 func (c Callback) GenCGoFunc() *jen.Statement {
-	return jen.Qual("C", c.ExternCName())
+	return jen.Qual("C", c.TrampolineCName())
+}
+
+// CallbackGenUserData generates the user data argument of the callback at the
+// given index into valueVar. The registry entry is deleted according to the
+// scope of the callback: right after the C call returns for "call", after the
+// first invocation for "async", and by the GDestroyNotify for "notified". If a
+// "notified" callback has no GDestroyNotify, then the entry is tied to obj
+// instead, unless obj is nil.
+func (c CallableAttrs) CallbackGenUserData(i int, fnValue, obj, valueVar *jen.Statement) *jen.Statement {
+	var callback = c.UserDataParameter(i)
+	if callback == nil {
+		return nil
+	}
+
+	var stmt = jen.Add(valueVar).Op(":=")
+
+	switch c.CallbackScope(*callback) {
+	case "call":
+		stmt.Add(CallbackGenAssign(fnValue))
+		stmt.Line()
+		stmt.Defer().Qual(CallbackImportPath(), "Delete").Call(
+			jen.Qual(CallbackImportPath(), "Handle").Call(valueVar),
+		)
+	case "async":
+		stmt.Add(CallbackGenAssignOnce(fnValue))
+	case "notified":
+		if !c.HasDestroyNotify() && obj != nil {
+			stmt.Add(CallbackGenAssignObject(obj, fnValue))
+			break
+		}
+		stmt.Add(CallbackGenAssign(fnValue))
+	default:
+		stmt.Add(CallbackGenAssign(fnValue))
+	}
+
+	return stmt
 }
 
 // CallbackGenAssign generates a call to callback.Assign with the given fnValue.
 func CallbackGenAssign(fnValue *jen.Statement) *jen.Statement {
 	return jen.Qual("C", "gpointer").Call(
-		jen.Qual("github.com/diamondburned/gspell/internal/callback", "Assign").Call(
+		jen.Qual(CallbackImportPath(), "Assign").Call(
+			fnValue,
+		),
+	)
+}
+
+// CallbackGenAssignOnce generates a call to callback.AssignOnce, which deletes
+// fnValue after its first invocation.
+func CallbackGenAssignOnce(fnValue *jen.Statement) *jen.Statement {
+	return jen.Qual("C", "gpointer").Call(
+		jen.Qual(CallbackImportPath(), "AssignOnce").Call(
+			fnValue,
+		),
+	)
+}
+
+// CallbackGenAssignObject generates a call to callback.AssignObject, which
+// deletes fnValue once the given object is finalized.
+func CallbackGenAssignObject(obj, fnValue *jen.Statement) *jen.Statement {
+	return jen.Qual("C", "gpointer").Call(
+		jen.Qual(CallbackImportPath(), "AssignObject").Call(
+			jen.Qual("unsafe", "Pointer").Call(obj),
 			fnValue,
 		),
 	)
 }
 
 func CallbackGenDelete() *jen.Statement {
-	return ZeroByteCast(jen.Qual("C", "callbackDelete"))
+	return ZeroByteCast(jen.Qual("C", CallbackDeleteTrampolineCName()))
+}
+
+// GenCallbackDelete generates the exported Go function that the delete
+// trampoline calls.
+func GenCallbackDelete() *jen.Statement {
+	s := jen.Comment("//export " + CallbackDeleteExternCName())
+	s.Line()
+	s.Func().Id(CallbackDeleteExternCName()).Params(jen.Id("handle").Qual("C", "uintptr_t")).Block(
+		jen.Qual(CallbackImportPath(), "Delete").Call(jen.Qual(CallbackImportPath(), "Handle").Call(jen.Id("handle"))),
+	)
+	s.Line()
+	return s
 }
 
 // ZeroByteCast works around Go misdetecting C function pointers as (*[0]byte).
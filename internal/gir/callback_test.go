@@ -0,0 +1,37 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestCallbackRecover checks that the exported function of a callback recovers
+// panics first thing, and that its result is unnamed, so it returns the C zero
+// value after a panic.
+func TestCallbackRecover(t *testing.T) {
+	callback := Callback{CallableAttrs: CallableAttrs{
+		Name: "WordFunc",
+		Parameters: &Parameters{Parameters: []Parameter{
+			{ParameterAttrs: ParameterAttrs{Name: "word", Type: Type{Name: "utf8", CType: "const gchar*"}}},
+			{ParameterAttrs: ParameterAttrs{Name: "user_data", Type: Type{Name: "gpointer", CType: "gpointer"}}},
+		}},
+		ReturnValue: &ReturnValue{Type: &Type{Name: "gboolean", CType: "gboolean"}},
+	}}
+
+	namespace := testNamespace()
+	namespace.Callbacks = []Callback{callback}
+	withNamespace(t, namespace)
+
+	got := fmt.Sprintf("%#v", callback.GenGlobalGoFunction())
+
+	want := "func gspell_callbackWordFunc(word *C.gchar, userData C.uintptr_t) C.gboolean {\n" +
+		"\tdefer callback.Recover(\"WordFunc\")\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("missing %q:\n%s", want, got)
+	}
+
+	if want := "panic(callback.ErrNotFound)"; !strings.Contains(got, want) {
+		t.Errorf("missing %q:\n%s", want, got)
+	}
+}
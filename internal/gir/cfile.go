@@ -0,0 +1,78 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// generatedHeader is the comment at the top of every generated file.
+const generatedHeader = "Code generated by girgen. DO NOT EDIT."
+
+// GenerateCHeader generates the C header included by the generated Go file.
+// It declares the C trampolines that are given to C in place of Go functions.
+func (n namespaceGenerator) GenerateCHeader(headerName string) []byte {
+	var guard = strings.ToUpper(strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' {
+			return '_'
+		}
+		return r
+	}, headerName))
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// %s\n\n", generatedHeader)
+	fmt.Fprintf(&b, "#ifndef %s\n#define %s\n\n", guard, guard)
+
+	// Handles are given to the exported Go functions as uintptr_t.
+	b.WriteString("#include <stdint.h>\n")
+
+	_, includes := CgoDirectives()
+	for _, include := range includes {
+		fmt.Fprintf(&b, "#include <%s>\n", include)
+	}
+
+	b.WriteString("\n// Deletes the callback in the registry that the user data points to. It is\n")
+	b.WriteString("// given to C as a GDestroyNotify.\n")
+	fmt.Fprintf(&b, "void %s(gpointer data);\n", CallbackDeleteTrampolineCName())
+
+	if closuresUsed {
+		b.WriteString("\n// Creates a GClosure calling the Go closure that the user data points to.\n")
+		fmt.Fprintf(&b, "%s;\n", GenClosureDecl())
+	}
+
+	if len(n.Callbacks) > 0 {
+		b.WriteString("\n// Trampolines of the callbacks, which call the exported Go functions.\n")
+		for _, callback := range n.Callbacks {
+			fmt.Fprintf(&b, "%s;\n", callback.GenTrampolineDecl())
+		}
+	}
+
+	fmt.Fprintf(&b, "\n#endif // %s\n", guard)
+
+	return []byte(b.String())
+}
+
+// GenerateCSource generates the C source that defines the trampolines declared
+// in the header. It is compiled by cgo alongside the generated Go file.
+func (n namespaceGenerator) GenerateCSource(headerName string) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// %s\n\n", generatedHeader)
+	b.WriteString("#include \"_cgo_export.h\"\n")
+	fmt.Fprintf(&b, "#include \"%s\"\n", headerName)
+
+	fmt.Fprintf(&b, "\nvoid %s(gpointer data) {\n\t%s((uintptr_t)data);\n}\n",
+		CallbackDeleteTrampolineCName(), CallbackDeleteExternCName())
+
+	if closuresUsed {
+		b.WriteString("\n")
+		b.WriteString(GenClosureSource())
+	}
+
+	for _, callback := range n.Callbacks {
+		b.WriteString("\n")
+		b.WriteString(callback.GenTrampoline())
+	}
+
+	return []byte(b.String())
+}
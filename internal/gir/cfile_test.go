@@ -0,0 +1,85 @@
+package gir
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestGenerateCSourceExports(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Callbacks = []Callback{{
+		CallableAttrs: CallableAttrs{
+			Name: "WordFunc",
+			Parameters: &Parameters{
+				Parameters: []Parameter{{
+					ParameterAttrs: ParameterAttrs{
+						Name: "user_data",
+						Type: Type{Name: "gpointer", CType: "gpointer"},
+					},
+				}},
+			},
+		},
+	}}
+	withNamespace(t, namespace)
+
+	source := string(activeNamespace.GenerateCSource("gspell_generated.h"))
+
+	for _, want := range []string{
+		"void gspell_trampoline_delete(gpointer data) {\n\tgspell_callbackDelete((uintptr_t)data);\n}",
+		"void gspell_trampoline_WordFunc(gpointer v0) {\n\tgspell_callbackWordFunc((uintptr_t)v0);\n}",
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("source doesn't contain %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestClosuresOnlyWhenUsed(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Classes = nil
+	withNamespace(t, namespace)
+	t.Cleanup(func() { closuresUsed = false })
+
+	// Nothing in the namespace creates GClosures, and whatever the previous
+	// namespace used is forgotten.
+	closuresUsed = true
+
+	var buf bytes.Buffer
+	f := jen.NewFile("gspell")
+	f.Add(activeNamespace.GenerateAll())
+	if err := f.Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	header := string(activeNamespace.GenerateCHeader("gspell_generated.h"))
+	source := string(activeNamespace.GenerateCSource("gspell_generated.h"))
+
+	for name, code := range map[string]string{"Go": buf.String(), "header": header, "source": source} {
+		if strings.Contains(code, "closure") {
+			t.Errorf("%s contains closure helpers:\n%s", name, code)
+		}
+	}
+
+	if got := GenClosureNew(jen.Id("fn")).GoString(); got != "closureNew(fn)" {
+		t.Errorf("got call %q", got)
+	}
+
+	header = string(activeNamespace.GenerateCHeader("gspell_generated.h"))
+	source = string(activeNamespace.GenerateCSource("gspell_generated.h"))
+
+	if want := "GClosure *gspell_closure_new(uintptr_t handle);"; !strings.Contains(header, want) {
+		t.Errorf("header doesn't contain %q:\n%s", want, header)
+	}
+
+	for _, want := range []string{
+		"gspell_closureMarshal((uintptr_t)closure->data, ",
+		"g_closure_add_finalize_notifier(closure, (gpointer)handle, gspell_closure_finalize);",
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("source doesn't contain %q:\n%s", want, source)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package gir
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GirDirs is the list of directories searched for the gir files of included
+// namespaces. DefaultGirDirs is searched after these.
+var GirDirs []string
+
+// repositoryDirs is searched before GirDirs. It contains the directory of the
+// gir file parsed by ParseRepositoryFile, so that the gir files installed next
+// to it are found first.
+var repositoryDirs []string
+
+// DefaultGirDirs returns the directories that gir files are installed to by
+// default, in the order of XDG_DATA_DIRS.
+func DefaultGirDirs() []string {
+	var dataDirs = os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+
+	var dirs []string
+	for _, dir := range filepath.SplitList(dataDirs) {
+		dirs = append(dirs, filepath.Join(dir, "gir-1.0"))
+	}
+
+	return dirs
+}
+
+// FindGirFile searches the directory of the parsed gir file, GirDirs and
+// DefaultGirDirs for the gir file of the given namespace. False is returned if
+// none is found.
+func FindGirFile(name, version string) (string, bool) {
+	var fileName = name + "-" + version + ".gir"
+
+	var dirs []string
+	dirs = append(dirs, repositoryDirs...)
+	dirs = append(dirs, GirDirs...)
+	dirs = append(dirs, DefaultGirDirs()...)
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, fileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// knownNamespace describes the cgo directives of a namespace without its gir
+// file.
+type knownNamespace struct {
+	Package  string
+	CInclude string
+	Includes []string
+}
+
+// knownNamespaces contains the namespaces that gotk3 binds, so that the
+// generated preamble doesn't depend on which gir files are installed.
+var knownNamespaces = map[string]knownNamespace{
+	"GLib-2.0":      {"glib-2.0", "glib.h", nil},
+	"GObject-2.0":   {"gobject-2.0", "glib-object.h", []string{"GLib-2.0"}},
+	"Gio-2.0":       {"gio-2.0", "gio/gio.h", []string{"GObject-2.0"}},
+	"cairo-1.0":     {"cairo-gobject", "cairo-gobject.h", nil},
+	"Pango-1.0":     {"pango", "pango/pango.h", []string{"GObject-2.0"}},
+	"GdkPixbuf-2.0": {"gdk-pixbuf-2.0", "gdk-pixbuf/gdk-pixbuf.h", []string{"Gio-2.0"}},
+	"Atk-1.0":       {"atk", "atk/atk.h", []string{"GObject-2.0"}},
+	"Gdk-3.0":       {"gdk-3.0", "gdk/gdk.h", []string{"cairo-1.0", "Pango-1.0", "GdkPixbuf-2.0", "Gio-2.0"}},
+	"Gtk-3.0":       {"gtk+-3.0", "gtk/gtk.h", []string{"Atk-1.0", "Gdk-3.0"}},
+}
+
+func (k knownNamespace) header() RepositoryHeader {
+	var header = RepositoryHeader{
+		Packages:  []Package{{Name: k.Package}},
+		CIncludes: []CInclude{{Name: k.CInclude}},
+	}
+
+	for _, include := range k.Includes {
+		parts := strings.SplitN(include, "-", 2)
+		header.Includes = append(header.Includes, Include{
+			Name:    parts[0],
+			Version: &parts[1],
+		})
+	}
+
+	return header
+}
+
+// includeHeader returns the header of the included namespace. False is returned
+// if it can't be found.
+func includeHeader(include Include) (RepositoryHeader, bool) {
+	var version string
+	if include.Version != nil {
+		version = *include.Version
+	}
+
+	if known, ok := knownNamespaces[include.Name+"-"+version]; ok {
+		return known.header(), true
+	}
+
+	path, ok := FindGirFile(include.Name, version)
+	if !ok {
+		return RepositoryHeader{}, false
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		warn(include.Name, "failed to open gir file: %v", err)
+		return RepositoryHeader{}, false
+	}
+	defer f.Close()
+
+	header, err := ParseRepositoryHeader(f)
+	if err != nil {
+		warn(include.Name, "failed to parse %s: %v", path, err)
+		return RepositoryHeader{}, false
+	}
+
+	return header, true
+}
+
+// cgoDirectives caches the result of CgoDirectives, so that the warnings are
+// only reported once. It is reset by ParseRepository.
+var cgoDirectives *[2][]string
+
+// CgoDirectives returns the pkg-config packages and the C headers of the parsed
+// repository, followed by the ones of the namespaces it includes.
+func CgoDirectives() (packages, includes []string) {
+	if cgoDirectives != nil {
+		return cgoDirectives[0], cgoDirectives[1]
+	}
+
+	defer func() { cgoDirectives = &[2][]string{packages, includes} }()
+
+	var seen = map[string]bool{}
+
+	var walk func(header RepositoryHeader)
+	walk = func(header RepositoryHeader) {
+		for _, pkg := range header.Packages {
+			if !seen["pkg:"+pkg.Name] {
+				seen["pkg:"+pkg.Name] = true
+				packages = append(packages, pkg.Name)
+			}
+		}
+
+		for _, include := range header.CIncludes {
+			if !seen["h:"+include.Name] {
+				seen["h:"+include.Name] = true
+				includes = append(includes, include.Name)
+			}
+		}
+
+		for _, include := range header.Includes {
+			var key = "gir:" + include.Name
+			if include.Version != nil {
+				key += "-" + *include.Version
+			}
+
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			dep, ok := includeHeader(include)
+			if !ok {
+				warn(include.Name, "cannot find the gir file; skipping its cgo directives")
+				continue
+			}
+
+			walk(dep)
+		}
+	}
+
+	walk(repository.RepositoryHeader)
+	return
+}
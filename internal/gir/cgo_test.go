@@ -0,0 +1,60 @@
+package gir
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindGirFileNextToRepository(t *testing.T) {
+	const gir = `<?xml version="1.0"?>
+<repository version="1.2" xmlns="http://www.gtk.org/introspection/core/1.0">
+  <namespace name="Test" version="1.0"/>
+</repository>`
+
+	writeGir := func(dir, name string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(gir), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	oldDataDirs, hadDataDirs := os.LookupEnv("XDG_DATA_DIRS")
+	os.Setenv("XDG_DATA_DIRS", t.TempDir())
+	t.Cleanup(func() {
+		if hadDataDirs {
+			os.Setenv("XDG_DATA_DIRS", oldDataDirs)
+		} else {
+			os.Unsetenv("XDG_DATA_DIRS")
+		}
+	})
+
+	oldDirs := GirDirs
+	GirDirs = nil
+	t.Cleanup(func() { GirDirs = oldDirs })
+
+	first, second := t.TempDir(), t.TempDir()
+	writeGir(first, "Dep-1.0.gir")
+
+	if err := ParseRepositoryFile(writeGir(first, "Test-1.0.gir")); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, ok := FindGirFile("Dep", "1.0"); !ok || filepath.Dir(path) != first {
+		t.Errorf("FindGirFile = %q, %v; want the file in %s", path, ok, first)
+	}
+
+	if err := ParseRepositoryFile(writeGir(second, "Test-1.0.gir")); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, ok := FindGirFile("Dep", "1.0"); ok {
+		t.Errorf("FindGirFile found %q next to the previous gir file", path)
+	}
+
+	if len(GirDirs) != 0 {
+		t.Errorf("GirDirs = %q, want it unchanged", GirDirs)
+	}
+}
@@ -3,7 +3,6 @@ package gir
 import (
 	"encoding/xml"
 	"fmt"
-	"log"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -26,6 +25,10 @@ type Class struct {
 	Methods      []Method      `xml:"http://www.gtk.org/introspection/core/1.0 method"`
 	Fields       []Field       `xml:"http://www.gtk.org/introspection/core/1.0 field"`
 	Functions    []Function    `xml:"http://www.gtk.org/introspection/core/1.0 function"`
+	Properties   []Property    `xml:"http://www.gtk.org/introspection/core/1.0 property"`
+	Signals      []Signal      `xml:"http://www.gtk.org/introspection/glib/1.0 signal"`
+
+	Doc *Doc
 	// Callbacks    []Callback    `xml:"http://www.gtk.org/introspection/core/1.0 callback"`
 }
 
@@ -49,13 +52,13 @@ func (c Class) GenerateAll() *jen.Statement {
 	f := new(jen.Statement)
 	f.Add(c.GenType())
 	f.Line()
-	f.Add(c.GenWrapper())
+	f.Add(genUnlessManual("", []string{c.WrapperFnName()}, c.GenWrapper))
 	f.Line()
-	f.Add(c.GenMarshaler())
+	f.Add(genUnlessManual("", []string{MarshalerFnName(c.GoName())}, c.GenMarshaler))
 	f.Line()
 	f.Add(c.GenConstructors())
 	f.Line()
-	f.Add(c.GenNative())
+	f.Add(genUnlessManual(c.GoName(), []string{"native"}, c.GenNative))
 	f.Line()
 	f.Add(c.GenFunctions())
 	f.Line()
@@ -95,8 +98,10 @@ func (c Class) GenType() *jen.Statement {
 	return jen.Type().Id(c.Name).Struct(append(fields, ifaceFields...)...)
 }
 
+// WrapperFnName returns the name of the wrapper function. It is exported so
+// that packages generated for dependent namespaces can wrap the class.
 func (c Class) WrapperFnName() string {
-	return "wrap" + c.GoName()
+	return "Wrap" + c.GoName()
 }
 
 func (c Class) GenWrapper() *jen.Statement {
@@ -146,11 +151,13 @@ func (c Class) GenMarshaler() *jen.Statement {
 func (c Class) GenConstructors() *jen.Statement {
 	var stmt = make(jen.Statement, 0, len(c.Constructors)*2)
 	for _, ctor := range c.Constructors {
-		if ctor.IsIgnored() {
+		if ctor.IsIgnored() || IsManual("", ctor.GoName()) {
 			continue
 		}
 
-		stmt.Add(ctor.GenFunc(c))
+		stmt.Add(genSymbol(symbolPath(c.Name, ctor.Name), ctor.Doc, func() *jen.Statement {
+			return ctor.GenFunc(c)
+		}))
 		stmt.Line()
 	}
 
@@ -172,12 +179,12 @@ func (c Class) GenNative() *jen.Statement {
 	var prnt = fieldNameFromType(c.ParentInstanceType())
 
 	switch goType := c.GoName(); {
-	// We can only use gwidget() if the class inherits gtk.Widget.
+	// We can only use WidgetPtr() if the class inherits gtk.Widget.
 	case EmbeddedFieldCheck(goType, "gtk.Widget"):
 		f.Block(
 			jen.Return(
 				jen.Parens(jen.Op("*").Qual("C", c.CType)).Call(
-					jen.Id("gwidget").Call(jen.Op("&").Id(i).Dot(prnt)),
+					jen.Qual(RuntimePath, "WidgetPtr").Call(jen.Op("&").Id(i).Dot(prnt)),
 				),
 			),
 		)
@@ -195,7 +202,7 @@ func (c Class) GenNative() *jen.Statement {
 		)
 
 	default:
-		log.Panicln("Unknown Native of type", goType)
+		fail("unknown native type %s", goType)
 	}
 
 	f.Line()
@@ -206,11 +213,11 @@ func (c Class) GenFunctions() *jen.Statement {
 	var f = new(jen.Statement)
 
 	for _, function := range c.Functions {
-		if function.IsIgnored() {
+		if function.IsIgnored() || IsManual("", function.GoName()) {
 			continue
 		}
 
-		f.Add(function.GenFunc())
+		f.Add(genSymbol(symbolPath(c.Name, function.Name), function.Doc, function.GenFunc))
 		f.Line()
 	}
 
@@ -220,11 +227,13 @@ func (c Class) GenFunctions() *jen.Statement {
 func (c Class) GenMethods() *jen.Statement {
 	var stmt = make(jen.Statement, 0, len(c.Methods)*3)
 	for _, method := range c.Methods {
-		if method.IsIgnored() {
+		if method.IsIgnored() || IsManual(c.Name, method.GoName()) {
 			continue
 		}
 
-		stmt.Add(method.GenFunc(c.Name))
+		stmt.Add(genSymbol(symbolPath(c.Name, method.Name), method.Doc, func() *jen.Statement {
+			return method.GenFunc(c.Name)
+		}))
 		stmt.Line()
 	}
 
@@ -0,0 +1,136 @@
+package gir
+
+import (
+	"fmt"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// closuresUsed is true if the generated code of the active namespace creates
+// GClosures, in which case the closure helpers are generated along with it. It
+// is reset by GenerateAll, so the C files must be generated after the Go file.
+var closuresUsed bool
+
+// GenClosureNew generates a call to closureNew, which creates a GClosure calling
+// fn, and makes the closure helpers generated. Generators must create GClosures
+// through it.
+func GenClosureNew(fn jen.Code) *jen.Statement {
+	closuresUsed = true
+	return jen.Id("closureNew").Call(fn)
+}
+
+// ClosureNewCName returns the name of the C function that creates a GClosure
+// calling a Go closure from the registry.
+func ClosureNewCName() string {
+	return cSymbolPrefix() + "_closure_new"
+}
+
+// ClosureMarshalExternCName returns the name of the exported Go function that
+// the GClosure marshaller calls.
+func ClosureMarshalExternCName() string {
+	return cSymbolPrefix() + "_closureMarshal"
+}
+
+// GenClosureDecl generates the C declaration of the GClosure constructor.
+func GenClosureDecl() string {
+	return fmt.Sprintf("GClosure *%s(uintptr_t handle)", ClosureNewCName())
+}
+
+// GenClosureSource generates the C GClosure marshaller, which gives the values
+// to the exported Go function, and the constructor of the GClosures using it.
+// The Go closure is deleted from the registry once the GClosure is finalized.
+func GenClosureSource() string {
+	var prefix = cSymbolPrefix()
+
+	return fmt.Sprintf(`static void %[1]s_closure_marshal(GClosure *closure, GValue *return_value,
+		guint n_param_values, const GValue *param_values,
+		gpointer invocation_hint, gpointer marshal_data) {
+	%[2]s((uintptr_t)closure->data, return_value, n_param_values, (GValue *)param_values);
+}
+
+static void %[1]s_closure_finalize(gpointer data, GClosure *closure) {
+	%[3]s((uintptr_t)data);
+}
+
+%[4]s {
+	GClosure *closure = g_closure_new_simple(sizeof(GClosure), (gpointer)handle);
+	g_closure_set_marshal(closure, %[1]s_closure_marshal);
+	g_closure_add_finalize_notifier(closure, (gpointer)handle, %[1]s_closure_finalize);
+	return closure;
+}
+`, prefix, ClosureMarshalExternCName(), CallbackDeleteExternCName(), GenClosureDecl())
+}
+
+// GenClosure generates the closureFunc type, the closureNew function that
+// turns it into a GClosure and the exported Go function of the marshaller.
+// GClosures are what signal handlers are connected with.
+func GenClosure() *jen.Statement {
+	var glib = "github.com/gotk3/gotk3/glib"
+
+	s := jen.Comment("closureFunc is the Go side of a GClosure created by closureNew. It's called")
+	s.Line()
+	s.Comment("with the return value to set, which is nil if there's none, and the")
+	s.Line()
+	s.Comment("parameters of the GClosure.")
+	s.Line()
+	s.Type().Id("closureFunc").Func().Params(
+		jen.Id("ret").Op("*").Qual(glib, "Value"),
+		jen.Id("params").Index().Op("*").Qual(glib, "Value"),
+	)
+	s.Line()
+	s.Line()
+
+	s.Comment("closureNew creates a GClosure, such as a signal handler, calling fn. fn is")
+	s.Line()
+	s.Comment("deleted from the callback registry once the GClosure is finalized.")
+	s.Line()
+	s.Func().Id("closureNew").Params(jen.Id("fn").Id("closureFunc")).Op("*").Qual("C", "GClosure").Block(
+		jen.Return(jen.Qual("C", ClosureNewCName()).Call(
+			jen.Qual("C", "uintptr_t").Call(jen.Qual(CallbackImportPath(), "Assign").Call(jen.Id("fn"))),
+		)),
+	)
+	s.Line()
+	s.Line()
+
+	s.Comment("//export " + ClosureMarshalExternCName())
+	s.Line()
+	s.Func().Id(ClosureMarshalExternCName()).Params(
+		jen.Id("handle").Qual("C", "uintptr_t"),
+		jen.Id("ret").Op("*").Qual("C", "GValue"),
+		jen.Id("nParams").Qual("C", "guint"),
+		jen.Id("params").Op("*").Qual("C", "GValue"),
+	).Block(
+		jen.Defer().Qual(CallbackImportPath(), "Recover").Call(jen.Lit("closure")),
+		jen.Line(),
+		jen.List(jen.Id("fn"), jen.Id("ok")).Op(":=").Qual(CallbackImportPath(), "Get").Call(
+			jen.Qual(CallbackImportPath(), "Handle").Call(jen.Id("handle")),
+		).Assert(jen.Id("closureFunc")),
+		jen.If(jen.Op("!").Id("ok")).Block(
+			jen.Panic(jen.Qual(CallbackImportPath(), "ErrNotFound")),
+		),
+		jen.Line(),
+		jen.Var().Id("goRet").Op("*").Qual(glib, "Value"),
+		jen.If(jen.Id("ret").Op("!=").Nil()).Block(
+			jen.Id("goRet").Op("=").Qual(glib, "ValueFromNative").Call(
+				jen.Qual("unsafe", "Pointer").Call(jen.Id("ret")),
+			),
+		),
+		jen.Line(),
+		jen.Id("cParams").Op(":=").Parens(
+			jen.Op("*").Index(jen.Lit(1).Op("<<").Lit(16)).Qual("C", "GValue"),
+		).Call(
+			jen.Qual("unsafe", "Pointer").Call(jen.Id("params")),
+		).Index(jen.Empty(), jen.Id("nParams"), jen.Id("nParams")),
+		jen.Id("goParams").Op(":=").Make(jen.Index().Op("*").Qual(glib, "Value"), jen.Id("nParams")),
+		jen.For(jen.Id("i").Op(":=").Range().Id("cParams")).Block(
+			jen.Id("goParams").Index(jen.Id("i")).Op("=").Qual(glib, "ValueFromNative").Call(
+				jen.Qual("unsafe", "Pointer").Call(jen.Op("&").Id("cParams").Index(jen.Id("i"))),
+			),
+		),
+		jen.Line(),
+		jen.Id("fn").Call(jen.Id("goRet"), jen.Id("goParams")),
+	)
+	s.Line()
+
+	return s
+}
@@ -29,8 +29,8 @@ func (c Constructor) GoName() string {
 
 func (c Constructor) GenFunc(class Class) *jen.Statement {
 	var s = new(jen.Statement)
-	if c.Doc != nil {
-		s.Add(c.Doc.GenGoComments("", c.GoName()))
+	if doc := c.GenGoDoc(0, "", c.GoName()); doc != nil {
+		s.Add(doc)
 	} else {
 		s.Add(GenCommentReflowLines(
 			c.GoName(),
@@ -0,0 +1,159 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity uint8
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", uint8(s))
+	}
+}
+
+// Diagnostic is a problem found while generating a symbol.
+type Diagnostic struct {
+	Severity Severity
+	// Path is the path of the gir element, such as "Gspell.Checker.check_word".
+	Path    string
+	Message string
+
+	// Filename and Line point to the C source of the element if the gir file
+	// has them.
+	Filename string
+	Line     int
+}
+
+func (d Diagnostic) String() string {
+	var b strings.Builder
+
+	if d.Filename != "" {
+		fmt.Fprintf(&b, "%s:%d: ", d.Filename, d.Line)
+	}
+
+	fmt.Fprintf(&b, "%s: ", d.Severity)
+
+	if d.Path != "" {
+		fmt.Fprintf(&b, "%s: ", d.Path)
+	}
+
+	b.WriteString(d.Message)
+	return b.String()
+}
+
+// KeepGoing, if true, makes the generator skip symbols that fail to generate
+// instead of aborting. The output is then a best-effort one.
+var KeepGoing bool
+
+var diagnostics []Diagnostic
+
+// Diagnostics returns all diagnostics reported so far.
+func Diagnostics() []Diagnostic {
+	return diagnostics
+}
+
+// HasErrors returns true if any error diagnostic was reported.
+func HasErrors() bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// symbolFailed returns the error diagnostic of the symbol at path if it failed
+// to generate and was skipped.
+func symbolFailed(path string) (Diagnostic, bool) {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError && d.Path == path {
+			return d, true
+		}
+	}
+	return Diagnostic{}, false
+}
+
+// ErrAborted is returned by GenerateToFile if a symbol failed to generate and
+// KeepGoing is false. The reason is in Diagnostics.
+var ErrAborted = errors.New("generation aborted")
+
+// symbolFailure is panicked by fail and recovered by genSymbol.
+type symbolFailure struct {
+	message string
+}
+
+// abortGeneration is panicked by genSymbol to stop generating altogether.
+type abortGeneration struct{}
+
+// fail aborts generating the current symbol with an error diagnostic.
+func fail(format string, v ...interface{}) {
+	panic(symbolFailure{fmt.Sprintf(format, v...)})
+}
+
+// warn reports a warning diagnostic for the given element path.
+func warn(path, format string, v ...interface{}) {
+	diagnostics = append(diagnostics, Diagnostic{
+		Severity: SeverityWarning,
+		Path:     path,
+		Message:  fmt.Sprintf(format, v...),
+	})
+}
+
+// symbolPath returns the element path of a symbol in the active namespace.
+func symbolPath(names ...string) string {
+	return activeNamespace.Name + "." + strings.Join(names, ".")
+}
+
+// genSymbol generates a single symbol using gen. If gen fails, then an error
+// diagnostic is reported for the symbol at path, and either an empty statement
+// is returned if KeepGoing is true or the whole generation is aborted.
+func genSymbol(path string, doc *Doc, gen func() *jen.Statement) (stmt *jen.Statement) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+
+		failure, ok := v.(symbolFailure)
+		if !ok {
+			panic(v)
+		}
+
+		var d = Diagnostic{
+			Severity: SeverityError,
+			Path:     path,
+			Message:  failure.message,
+		}
+
+		if doc != nil {
+			d.Filename = doc.Filename
+			d.Line = doc.Line
+		}
+
+		diagnostics = append(diagnostics, d)
+
+		if !KeepGoing {
+			panic(abortGeneration{})
+		}
+
+		stmt = new(jen.Statement)
+	}()
+
+	return gen()
+}
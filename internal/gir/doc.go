@@ -2,14 +2,11 @@ package gir
 
 import (
 	"encoding/xml"
-	"fmt"
-	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/dave/jennifer/jen"
-	"github.com/mitchellh/go-wordwrap"
 )
 
 type Doc struct {
@@ -29,19 +26,6 @@ func (d Doc) Lower() string {
 	return d.String
 }
 
-var (
-	cmtNamespaceRegex = regexp.MustCompile(`#[A-Z]\w+?[A-Z]`)
-	cmtArgumentsRegex = regexp.MustCompile(`@\w+`)
-	cmtPrimitiveRegex = regexp.MustCompile(`%\w+`)
-	cmtFunctionsRegex = regexp.MustCompile(`\w+\(\)`)
-	cmtMdHeadingRegex = regexp.MustCompile(`#+ `)
-	cmtOpenBlockRegex = regexp.MustCompile(`(?ms)\|\[(?:&lt;!--.*--&gt;\n)?(.*)(?:\]\|)?`)
-	cmtWhitespaceProc = strings.NewReplacer(
-		"\n\n", "\n\n",
-		"\n", " ",
-	)
-)
-
 // GenGoComments generates comments in idiomatic Go style. The given selfName
 // replaces @self with the given receiver.
 func (d Doc) GenGoComments(selfName, prefix string) *jen.Statement {
@@ -53,17 +37,7 @@ func (d Doc) GenGoCommentsIndent(indentLvl uint, selfName, prefix string) *jen.S
 		return nil
 	}
 
-	cmt := d.Lower()
-
-	// Replace @self with the given receiver name.
-	cmt = cmtArgumentsRegex.ReplaceAllStringFunc(cmt, func(str string) string {
-		if str == "@self" && selfName != "" {
-			return selfName
-		}
-		return str[1:]
-	})
-
-	return GenCommentReflowLinesIndent(indentLvl, prefix, cmt)
+	return genGtkDocIndent(indentLvl, selfName, prefix, d.Lower())
 }
 
 func (d Doc) GenComments() *jen.Statement {
@@ -75,115 +49,28 @@ const (
 	CommentsTabWidth    = 4
 )
 
+// GenCommentReflowLinesIndent parses the given gtk-doc comment and renders it
+// as a Go doc comment, prefixed with the given prefix.
 func GenCommentReflowLinesIndent(indentLvl uint, prefix, cmt string) *jen.Statement {
-	var columns = CommentsColumnLimit - (CommentsTabWidth * indentLvl)
+	return genGtkDocIndent(indentLvl, "", prefix, cmt)
+}
 
+func genGtkDocIndent(indentLvl uint, selfName, prefix, cmt string) *jen.Statement {
 	// Trim the word "this" away to make the sentence gramatically correct.
 	cmt = strings.TrimPrefix(cmt, "this ")
 
-	// Wipe the namespace.
-	cmt = cmtNamespaceRegex.ReplaceAllStringFunc(cmt, func(str string) string {
-		// Return only the last character.
-		return str[len(str)-1:]
-	})
-
-	// Replace snake-cased functions with known ones in the namespace. Prepend a
-	// C prefix otherwise.
-	cmt = cmtFunctionsRegex.ReplaceAllStringFunc(cmt, func(str string) string {
-		var fnName = strings.TrimSuffix(str, "()")
-
-		switch fn := activeNamespace.FnWithC(fnName); fn := fn.(type) {
-		case Method:
-			if fn.Parameters.HasInstanceParameter() {
-				return fmt.Sprintf(
-					"(%s).%s()",
-					fn.Parameters.InstanceParameter.Type.GoType(),
-					fn.GoName(),
-				)
-			}
-		case GoNamer:
-			return fmt.Sprintf("%s()", fn.GoName())
-		}
-
-		return fmt.Sprintf("C.%s()", fnName)
-	})
-
-	// Replace C primitives with Go's.
-	cmt = cmtPrimitiveRegex.ReplaceAllStringFunc(cmt, func(str string) string {
-		// [:1] trims the % away.
-		switch str = str[1:]; str {
-		case "NULL":
-			return "nil"
-		case "TRUE":
-			return "true"
-		case "FALSE":
-			return "false"
-		default:
-			return str
-		}
-	})
-
-	// Split into paragraphs and parse each of them.
-	var paragraphs = strings.Split(cmt, "\n\n")
-	var codeblock = false
-
-	for i, paragraph := range paragraphs {
-		switch codeblockChange := openOrCloseCodeblock(paragraph); {
-		// Codeblock:
-		case codeblock || codeblockChange:
-			// Toggle codeblock state.
-			if codeblockChange {
-				codeblock = !codeblock
-			}
-
-			// Parse the codeblock.
-			var lines []string
-			if m := cmtOpenBlockRegex.FindStringSubmatch(paragraph); m != nil {
-				lines = strings.Split(m[1], "\n")
-			} else {
-				paragraph = strings.TrimSuffix(paragraph, "]|")
-				lines = strings.Split(paragraph, "\n")
-			}
-
-			// Render the codeblock in GoDoc markup.
-			for i, line := range lines {
-				lines[i] = "   " + line
-			}
-			paragraphs[i] = strings.Join(lines, "\n")
-
-		// Headings;
-		case strings.HasPrefix(paragraph, "#"):
-			// Go's heading syntax doesn't require the hash.
-			paragraph = cmtMdHeadingRegex.ReplaceAllString(paragraph, "")
-			// Ensure there's no period.
-			paragraphs[i] = strings.TrimSuffix(paragraph, ".")
-
-		case !codeblock:
-			fallthrough
-
-		// Normal paragraphs.
-		default:
-			paragraph = strings.TrimSpace(cmtWhitespaceProc.Replace(paragraph))
-			if i == 0 {
-				paragraph = fmt.Sprintf("%s %s", prefix, paragraph)
-			}
-			paragraph = wordwrap.WrapString(paragraph, columns)
-			paragraphs[i] = paragraph
-		}
-	}
-
-	cmt = strings.Join(paragraphs, "\n\n")
-
-	var stmt = new(jen.Statement)
-	for _, line := range strings.Split(cmt, "\n") {
-		stmt.Comment(line).Line()
-	}
+	return genDocBlocksIndent(indentLvl, selfName, prefix, parseGtkDoc(cmt))
+}
 
-	return stmt
+func genDocBlocksIndent(indentLvl uint, selfName, prefix string, blocks []docBlock) *jen.Statement {
+	var columns = CommentsColumnLimit - (CommentsTabWidth * indentLvl)
+	var conv = gtkdocConverter{selfName: selfName}
+	return genCommentLines(conv.render(blocks, columns, prefix))
 }
 
-func openOrCloseCodeblock(paragraph string) bool {
-	return strings.HasPrefix(paragraph, "|[") || strings.HasSuffix(paragraph, "]|")
+// sinceBlock returns the paragraph noting the version something was added in.
+func sinceBlock(version string) docBlock {
+	return docBlock{Kind: docParagraph, Lines: []string{"Since: " + version}}
 }
 
 func GenCommentReflowLines(prefix, cmt string) *jen.Statement {
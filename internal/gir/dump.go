@@ -0,0 +1,414 @@
+package gir
+
+// DumpSchemaVersion is the version of the JSON schema of Dump. It is bumped on
+// every change that breaks existing consumers; adding fields doesn't.
+const DumpSchemaVersion = 1
+
+// Dump is the resolved model of a namespace, meant to be marshaled into JSON
+// for tools that don't want to parse the gir file themselves.
+type Dump struct {
+	SchemaVersion int    `json:"schema_version"`
+	Namespace     string `json:"namespace"`
+	Version       string `json:"version"`
+
+	Classes    []DumpObject   `json:"classes"`
+	Interfaces []DumpObject   `json:"interfaces"`
+	Records    []DumpObject   `json:"records"`
+	Enums      []DumpEnum     `json:"enums"`
+	Functions  []DumpCallable `json:"functions"`
+	Callbacks  []DumpCallable `json:"callbacks"`
+}
+
+// DumpDoc is a documentation string along with its position in the C source.
+type DumpDoc struct {
+	Text     string `json:"text"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// DumpType is a type as written in the gir file and as generated in Go. GoType
+// is empty if the type can't be mapped.
+type DumpType struct {
+	Name   string `json:"name"`
+	CType  string `json:"c_type,omitempty"`
+	GoType string `json:"go_type,omitempty"`
+}
+
+// DumpParameter is a single parameter of a callable.
+type DumpParameter struct {
+	Name     string   `json:"name"`
+	GoName   string   `json:"go_name"`
+	Type     DumpType `json:"type"`
+	Nullable bool     `json:"nullable,omitempty"`
+	Transfer string   `json:"transfer,omitempty"`
+	Ignored  bool     `json:"ignored,omitempty"`
+	Doc      *DumpDoc `json:"doc,omitempty"`
+}
+
+// DumpReturn is the return value of a callable.
+type DumpReturn struct {
+	Type     DumpType `json:"type"`
+	Nullable bool     `json:"nullable,omitempty"`
+	Transfer string   `json:"transfer,omitempty"`
+	Doc      *DumpDoc `json:"doc,omitempty"`
+}
+
+// DumpCallable is a constructor, method, function or callback. Manual is true
+// if it is hand-written, which is only known after ScanManualDecls.
+type DumpCallable struct {
+	Name         string          `json:"name"`
+	CIdentifier  string          `json:"c_identifier,omitempty"`
+	GoName       string          `json:"go_name"`
+	Version      string          `json:"version,omitempty"`
+	Throws       bool            `json:"throws,omitempty"`
+	Ignored      bool            `json:"ignored"`
+	IgnoreReason string          `json:"ignore_reason,omitempty"`
+	Manual       bool            `json:"manual,omitempty"`
+	Parameters   []DumpParameter `json:"parameters"`
+	Returns      *DumpReturn     `json:"returns,omitempty"`
+	Doc          *DumpDoc        `json:"doc,omitempty"`
+}
+
+// DumpProperty is a GObject property.
+type DumpProperty struct {
+	Name          string   `json:"name"`
+	Type          DumpType `json:"type"`
+	Version       string   `json:"version,omitempty"`
+	Readable      bool     `json:"readable"`
+	Writable      bool     `json:"writable"`
+	Construct     bool     `json:"construct,omitempty"`
+	ConstructOnly bool     `json:"construct_only,omitempty"`
+	Doc           *DumpDoc `json:"doc,omitempty"`
+}
+
+// DumpSignal is a GObject signal.
+type DumpSignal struct {
+	Name       string          `json:"name"`
+	When       string          `json:"when,omitempty"`
+	Version    string          `json:"version,omitempty"`
+	Parameters []DumpParameter `json:"parameters"`
+	Returns    *DumpReturn     `json:"returns,omitempty"`
+	Doc        *DumpDoc        `json:"doc,omitempty"`
+}
+
+// DumpObject is a class, interface or record.
+type DumpObject struct {
+	Name         string `json:"name"`
+	CType        string `json:"c_type"`
+	GoName       string `json:"go_name"`
+	GLibTypeName string `json:"glib_type_name,omitempty"`
+	GLibGetType  string `json:"glib_get_type,omitempty"`
+	Parent       string `json:"parent,omitempty"`
+	Ignored      bool   `json:"ignored"`
+	IgnoreReason string `json:"ignore_reason,omitempty"`
+
+	Implements   []string       `json:"implements,omitempty"`
+	Constructors []DumpCallable `json:"constructors,omitempty"`
+	Methods      []DumpCallable `json:"methods,omitempty"`
+	Functions    []DumpCallable `json:"functions,omitempty"`
+	Properties   []DumpProperty `json:"properties,omitempty"`
+	Signals      []DumpSignal   `json:"signals,omitempty"`
+
+	Doc *DumpDoc `json:"doc,omitempty"`
+}
+
+// DumpMember is a member of an enum.
+type DumpMember struct {
+	Name        string   `json:"name"`
+	CIdentifier string   `json:"c_identifier"`
+	GoName      string   `json:"go_name"`
+	Value       int      `json:"value"`
+	Nick        string   `json:"nick"`
+	Doc         *DumpDoc `json:"doc,omitempty"`
+}
+
+// DumpEnum is an enum or a bitfield.
+type DumpEnum struct {
+	Name         string       `json:"name"`
+	CType        string       `json:"c_type"`
+	GoName       string       `json:"go_name"`
+	GLibTypeName string       `json:"glib_type_name,omitempty"`
+	GLibGetType  string       `json:"glib_get_type,omitempty"`
+	Version      string       `json:"version,omitempty"`
+	Flags        bool         `json:"flags"`
+	Members      []DumpMember `json:"members"`
+	Doc          *DumpDoc     `json:"doc,omitempty"`
+}
+
+// Dump returns the resolved model of the namespace.
+func (n namespaceGenerator) Dump() Dump {
+	var d = Dump{
+		SchemaVersion: DumpSchemaVersion,
+		Namespace:     n.Name,
+		Version:       n.Version,
+		Classes:       []DumpObject{},
+		Interfaces:    []DumpObject{},
+		Records:       []DumpObject{},
+		Enums:         []DumpEnum{},
+		Functions:     []DumpCallable{},
+		Callbacks:     []DumpCallable{},
+	}
+
+	for _, class := range n.Classes {
+		var obj = DumpObject{
+			Name:         class.Name,
+			CType:        class.CType,
+			GoName:       class.GoName(),
+			GLibTypeName: class.GLibTypeName,
+			GLibGetType:  class.GLibGetType,
+			Parent:       class.Parent,
+			Doc:          dumpDoc(class.Doc),
+		}
+
+		for _, impl := range class.Implements {
+			obj.Implements = append(obj.Implements, impl.Name)
+		}
+		for _, ctor := range class.Constructors {
+			obj.Constructors = append(obj.Constructors,
+				dumpCallable(ctor.GoName(), ctor.CallableAttrs))
+		}
+		for _, method := range class.Methods {
+			obj.Methods = append(obj.Methods, dumpMethod(class.Name, method))
+		}
+		for _, function := range class.Functions {
+			obj.Functions = append(obj.Functions,
+				dumpCallable(function.GoName(), function.CallableAttrs))
+		}
+
+		obj.Properties = dumpProperties(class.Properties)
+		obj.Signals = dumpSignals(class.Signals)
+
+		d.Classes = append(d.Classes, obj)
+	}
+
+	for _, iface := range n.Interfaces {
+		var obj = DumpObject{
+			Name:         iface.Name,
+			CType:        iface.CType,
+			GoName:       iface.GoName(),
+			GLibTypeName: iface.GLibTypeName,
+			GLibGetType:  iface.GLibGetType,
+			Doc:          dumpDoc(iface.Doc),
+		}
+
+		for _, prereq := range iface.Prerequisites {
+			obj.Implements = append(obj.Implements, prereq.Name)
+		}
+		for _, method := range iface.Methods {
+			obj.Methods = append(obj.Methods, dumpMethod(iface.GoName(), method))
+		}
+		for _, function := range iface.Functions {
+			obj.Functions = append(obj.Functions,
+				dumpCallable(function.GoName(), function.CallableAttrs))
+		}
+
+		obj.Properties = dumpProperties(iface.Properties)
+		obj.Signals = dumpSignals(iface.Signals)
+
+		d.Interfaces = append(d.Interfaces, obj)
+	}
+
+	for _, record := range n.Records {
+		var obj = DumpObject{
+			Name:         record.Name,
+			CType:        record.CType,
+			GoName:       record.GoName(),
+			GLibTypeName: record.GLibTypeName,
+			GLibGetType:  record.GLibGetType,
+			Ignored:      record.IsIgnored(),
+			Doc:          dumpDoc(record.Doc),
+		}
+
+		if obj.Ignored {
+			obj.IgnoreReason = reasonNoGType
+		}
+
+		for _, method := range record.Methods {
+			obj.Methods = append(obj.Methods, dumpMethod(record.Name, method))
+		}
+		for _, function := range record.Functions {
+			obj.Functions = append(obj.Functions,
+				dumpCallable(function.GoName(), function.CallableAttrs))
+		}
+
+		d.Records = append(d.Records, obj)
+	}
+
+	for _, enum := range n.AllEnums() {
+		var e = DumpEnum{
+			Name:         enum.Name,
+			CType:        enum.CType,
+			GoName:       enum.GoName(),
+			GLibTypeName: enum.GLibTypeName,
+			GLibGetType:  enum.GLibGetType,
+			Version:      enum.Version,
+			Flags:        enum.IsFlags(),
+			Members:      make([]DumpMember, 0, len(enum.Members)),
+			Doc:          dumpDoc(enum.Doc),
+		}
+
+		for _, member := range enum.Members {
+			e.Members = append(e.Members, DumpMember{
+				Name:        member.Name,
+				CIdentifier: member.CIdentifier,
+				GoName:      enum.MemberGoName(member),
+				Value:       member.Value,
+				Nick:        member.Nick(),
+				Doc:         dumpDoc(member.Doc),
+			})
+		}
+
+		d.Enums = append(d.Enums, e)
+	}
+
+	for _, function := range n.Functions {
+		d.Functions = append(d.Functions,
+			dumpCallable(function.GoName(), function.CallableAttrs))
+	}
+
+	for _, callback := range n.Callbacks {
+		d.Callbacks = append(d.Callbacks,
+			dumpCallable(callback.GoName(), callback.CallableAttrs))
+	}
+
+	return d
+}
+
+func dumpDoc(doc *Doc) *DumpDoc {
+	if doc == nil {
+		return nil
+	}
+
+	return &DumpDoc{
+		Text:     gtkdocEntities.Replace(doc.String),
+		Filename: doc.Filename,
+		Line:     doc.Line,
+	}
+}
+
+func dumpType(t Type) DumpType {
+	var goType string
+	if stmt := t.Type(); stmt != nil {
+		goType = stmt.GoString()
+	}
+
+	return DumpType{
+		Name:   t.Name,
+		CType:  t.CType,
+		GoType: goType,
+	}
+}
+
+func dumpTransfer(t TransferOwnership) string {
+	if t.TransferOwnership == nil {
+		return ""
+	}
+	return *t.TransferOwnership
+}
+
+// dumpMethod dumps the method with its own name and C identifier, since they
+// shadow the ones in CallableAttrs. The receiver is the name that hand-written
+// methods are looked up with, like in Report.
+func dumpMethod(recv string, m Method) DumpCallable {
+	c := dumpCallable(m.GoName(), m.CallableAttrs)
+	c.Name = m.Name
+	c.CIdentifier = m.CIdentifier
+	c.Manual = c.IgnoreReason == "" && IsManual(recv, m.GoName())
+	return c
+}
+
+func dumpCallable(goName string, c CallableAttrs) DumpCallable {
+	return DumpCallable{
+		Name:         c.Name,
+		CIdentifier:  c.CIdentifier,
+		GoName:       goName,
+		Version:      c.Version,
+		Throws:       c.Throws,
+		Ignored:      c.IsIgnored(),
+		IgnoreReason: c.IgnoreReason(),
+		Manual:       c.IgnoreReason() == "" && IsManual("", goName),
+		Parameters:   dumpParameters(c.Parameters),
+		Returns:      dumpReturn(c.ReturnValue),
+		Doc:          dumpDoc(c.Doc),
+	}
+}
+
+func dumpParameters(p *Parameters) []DumpParameter {
+	var params = []DumpParameter{}
+	if p == nil {
+		return params
+	}
+
+	for _, param := range p.Parameters {
+		params = append(params, DumpParameter{
+			Name:     param.Name,
+			GoName:   param.GoName(),
+			Type:     dumpType(param.Type),
+			Nullable: param.IsNullable(),
+			Transfer: dumpTransfer(param.TransferOwnership),
+			Ignored:  param.IsIgnored(),
+			Doc:      dumpDoc(param.Doc),
+		})
+	}
+
+	return params
+}
+
+func dumpReturn(r *ReturnValue) *DumpReturn {
+	if r == nil {
+		return nil
+	}
+
+	var ret = DumpReturn{
+		Nullable: r.IsNullable(),
+		Transfer: dumpTransfer(r.TransferOwnership),
+		Doc:      dumpDoc(r.Doc),
+	}
+
+	switch {
+	case r.Type != nil:
+		ret.Type = dumpType(*r.Type)
+	case r.Array != nil:
+		ret.Type = DumpType{
+			Name:  "array",
+			CType: r.Array.CType,
+		}
+		if elem := dumpType(r.Array.Type); elem.GoType != "" {
+			ret.Type.GoType = "[]" + elem.GoType
+		}
+	}
+
+	return &ret
+}
+
+func dumpProperties(props []Property) []DumpProperty {
+	var dumped []DumpProperty
+	for _, prop := range props {
+		dumped = append(dumped, DumpProperty{
+			Name:          prop.Name,
+			Type:          dumpType(prop.Type),
+			Version:       prop.Version,
+			Readable:      prop.IsReadable(),
+			Writable:      prop.Writable,
+			Construct:     prop.Construct,
+			ConstructOnly: prop.ConstructOnly,
+			Doc:           dumpDoc(prop.Doc),
+		})
+	}
+	return dumped
+}
+
+func dumpSignals(signals []Signal) []DumpSignal {
+	var dumped []DumpSignal
+	for _, signal := range signals {
+		dumped = append(dumped, DumpSignal{
+			Name:       signal.Name,
+			When:       signal.When,
+			Version:    signal.Version,
+			Parameters: dumpParameters(signal.Parameters),
+			Returns:    dumpReturn(signal.ReturnValue),
+			Doc:        dumpDoc(signal.Doc),
+		})
+	}
+	return dumped
+}
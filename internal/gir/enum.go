@@ -2,6 +2,7 @@ package gir
 
 import (
 	"encoding/xml"
+	"strings"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -10,7 +11,8 @@ type Member struct {
 	XMLName     xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 member"`
 	Name        string   `xml:"name,attr"`
 	Value       int      `xml:"value,attr"`
-	CIdentifier string   `xml:"http://www.gtk.org/introspection/c/1.0 identifer,attr"`
+	CIdentifier string   `xml:"http://www.gtk.org/introspection/c/1.0 identifier,attr"`
+	GLibNick    string   `xml:"http://www.gtk.org/introspection/glib/1.0 nick,attr"`
 
 	Doc *Doc
 }
@@ -19,6 +21,15 @@ func (m Member) GoName() string {
 	return snakeToGo(true, m.Name)
 }
 
+// Nick returns the GLib nick of the member. If the gir file doesn't have one,
+// then it is guessed from the name the same way GLib does.
+func (m Member) Nick() string {
+	if m.GLibNick != "" {
+		return m.GLibNick
+	}
+	return strings.ReplaceAll(m.Name, "_", "-")
+}
+
 type Enum struct {
 	XMLName xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 enumeration"`
 	Name    string   `xml:"name,attr"` // Go case
@@ -32,19 +43,61 @@ type Enum struct {
 	CType string `xml:"http://www.gtk.org/introspection/c/1.0 type,attr"`
 
 	Members []Member `xml:"http://www.gtk.org/introspection/core/1.0 member"`
+
+	// flags is true if the enum is a bitfield.
+	flags bool
+}
+
+// Bitfield is an enum whose members are flags that can be OR'd together.
+type Bitfield struct {
+	XMLName xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 bitfield"`
+	Enum
+}
+
+// AsEnum returns the bitfield as an Enum that generates flag types.
+func (b Bitfield) AsEnum() Enum {
+	var e = b.Enum
+	e.flags = true
+	return e
 }
 
 func (e Enum) GoName() string {
 	return snakeToGo(true, e.Name)
 }
 
+// IsFlags returns true if the enum is a bitfield.
+func (e Enum) IsFlags() bool {
+	return e.flags
+}
+
 func (e Enum) GenerateAll() *jen.Statement {
+	var goName = e.GoName()
+
 	f := new(jen.Statement)
 	f.Add(e.GenType())
 	f.Line()
-	f.Add(e.GenMarshaler())
-	f.Line()
+	if e.GLibGetType != "" {
+		f.Add(genUnlessManual("", []string{MarshalerFnName(goName)}, e.GenMarshaler))
+		f.Line()
+	}
 	f.Add(e.GenConsts())
+	f.Line()
+	f.Add(genUnlessManual("", []string{e.valuesFnName()}, e.GenValues))
+	f.Line()
+	f.Add(genUnlessManual(goName, []string{"nick"}, e.GenNick))
+	f.Line()
+	f.Add(genUnlessManual("", []string{e.parseFnName()}, e.GenParse))
+	f.Line()
+	f.Add(genUnlessManual(goName, []string{"String"}, e.GenString))
+	f.Line()
+	f.Add(genUnlessManual(goName, []string{"IsValid"}, e.GenIsValid))
+	f.Line()
+	f.Add(genUnlessManual(goName, []string{"MarshalText", "UnmarshalText"}, e.GenTextMarshalers))
+	f.Line()
+	// Enums without a GType can't be put in a GValue.
+	if e.GLibGetType != "" {
+		f.Add(genUnlessManual(goName, []string{"ToGValue"}, e.GenToGValue))
+	}
 	return f
 }
 
@@ -54,10 +107,15 @@ func (e Enum) GenMarshalerItem() *jen.Statement {
 
 func (e Enum) GenMarshaler() *jen.Statement {
 	var goName = e.GoName()
+	var getter = "g_value_get_enum"
+	if e.flags {
+		getter = "g_value_get_flags"
+	}
+
 	return GenMarshalerFn(goName,
 		jen.Return(
 			jen.Id(goName).Call(
-				jen.Qual("C", "g_value_get_enum").Call(
+				jen.Qual("C", getter).Call(
 					jen.Parens(jen.Op("*").Qual("C", "GValue")).Call(
 						jen.Qual("unsafe", "Pointer").Call(jen.Id("p")),
 					),
@@ -70,8 +128,24 @@ func (e Enum) GenMarshaler() *jen.Statement {
 
 func (e Enum) GenType() *jen.Statement {
 	var s = new(jen.Statement)
-	if e.Doc != nil {
-		s.Add(e.Doc.GenGoComments("", e.GoName()))
+	var blocks []docBlock
+	var prefix string
+
+	if e.Doc != nil && e.Doc.String != "" {
+		blocks = parseGtkDoc(strings.TrimPrefix(e.Doc.Lower(), "this "))
+		prefix = e.GoName()
+	}
+
+	if e.Version != "" {
+		blocks = append(blocks, sinceBlock(e.Version))
+	}
+
+	if len(blocks) > 0 {
+		s.Add(genDocBlocksIndent(0, "", prefix, blocks))
+	}
+
+	if e.flags {
+		return s.Type().Id(e.GoName()).Uint()
 	}
 
 	return s.Type().Id(e.GoName()).Int()
@@ -82,7 +156,7 @@ func (e Enum) GenConsts() *jen.Statement {
 
 	return jen.Const().DefsFunc(func(g *jen.Group) {
 		for _, member := range e.Members {
-			var fullName = enumName + member.GoName()
+			var fullName = e.MemberGoName(member)
 
 			var s = new(jen.Statement)
 			if member.Doc != nil {
@@ -94,3 +168,258 @@ func (e Enum) GenConsts() *jen.Statement {
 		}
 	})
 }
+
+// MemberGoName returns the Go constant name of the given member.
+func (e Enum) MemberGoName(member Member) string {
+	return e.GoName() + member.GoName()
+}
+
+// uniqueMembers returns the members with duplicate values filtered out, since
+// they cannot appear twice in a switch.
+func (e Enum) uniqueMembers() []Member {
+	var members = make([]Member, 0, len(e.Members))
+	var seen = make(map[int]bool, len(e.Members))
+
+	for _, member := range e.Members {
+		if !seen[member.Value] {
+			seen[member.Value] = true
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+func (e Enum) valuesFnName() string {
+	return e.GoName() + "Values"
+}
+
+func (e Enum) parseFnName() string {
+	return "parse" + e.GoName()
+}
+
+// GenValues generates a function that returns all known values of the enum.
+func (e Enum) GenValues() *jen.Statement {
+	var goName = e.GoName()
+
+	s := GenCommentReflowLines(e.valuesFnName(), "returns all known values of "+goName+".")
+	s.Func().Id(e.valuesFnName()).Params().Index().Id(goName).Block(
+		jen.Return(jen.Index().Id(goName).ValuesFunc(func(g *jen.Group) {
+			for _, member := range e.Members {
+				g.Id(e.MemberGoName(member))
+			}
+		})),
+	)
+	s.Line()
+
+	return s
+}
+
+// GenNick generates the unexported method that returns the GLib nick of a
+// value.
+func (e Enum) GenNick() *jen.Statement {
+	var goName = e.GoName()
+	var recv = firstChar(e.Name)
+	var members = e.uniqueMembers()
+
+	s := GenCommentReflowLines("nick", "returns the GLib nick of a single value or an empty string.")
+	s.Func().Params(jen.Id(recv).Id(goName)).Id("nick").Params().String().Block(
+		jen.Switch(jen.Id(recv)).BlockFunc(func(g *jen.Group) {
+			for _, member := range members {
+				g.Case(jen.Id(e.MemberGoName(member))).Block(jen.Return(jen.Lit(member.Nick())))
+			}
+			g.Default().Block(jen.Return(jen.Lit("")))
+		}),
+	)
+	s.Line()
+
+	return s
+}
+
+// GenParse generates the unexported function that parses a single GLib nick.
+func (e Enum) GenParse() *jen.Statement {
+	var goName = e.GoName()
+
+	s := GenCommentReflowLines(e.parseFnName(), "parses a single GLib nick.")
+	s.Func().Id(e.parseFnName()).Params(jen.Id("nick").String()).Params(jen.Id(goName), jen.Bool()).Block(
+		jen.Switch(jen.Id("nick")).BlockFunc(func(g *jen.Group) {
+			for _, member := range e.Members {
+				g.Case(jen.Lit(member.Nick())).Block(jen.Return(jen.Id(e.MemberGoName(member)), jen.True()))
+			}
+			g.Default().Block(jen.Return(jen.Lit(0), jen.False()))
+		}),
+	)
+	s.Line()
+
+	return s
+}
+
+// GenString generates the String method. Flags are printed as their nicks
+// joined with "|".
+func (e Enum) GenString() *jen.Statement {
+	var goName = e.GoName()
+	var recv = jen.Id(firstChar(e.Name))
+
+	s := GenCommentReflowLines("String", "returns the GLib nick of "+goName+".")
+	s.Func().Params(recv.Clone().Id(goName)).Id("String").Params().String()
+
+	if !e.flags {
+		s.Block(
+			jen.If(jen.Id("nick").Op(":=").Add(recv).Dot("nick").Call(), jen.Id("nick").Op("!=").Lit("")).Block(
+				jen.Return(jen.Id("nick")),
+			),
+			jen.Return(jen.Qual("fmt", "Sprintf").Call(jen.Lit(goName+"(%d)"), jen.Int().Call(recv))),
+		)
+		s.Line()
+		return s
+	}
+
+	s.Block(
+		jen.If(jen.Id("nick").Op(":=").Add(recv).Dot("nick").Call(), jen.Id("nick").Op("!=").Lit("")).Block(
+			jen.Return(jen.Id("nick")),
+		),
+		jen.Line(),
+		jen.Var().Id("nicks").Index().String(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("v")).Op(":=").Range().Id(e.valuesFnName()).Call()).Block(
+			jen.If(jen.Id("v").Op("!=").Lit(0).Op("&&").Add(recv).Op("&").Id("v").Op("==").Id("v")).Block(
+				jen.Id("nicks").Op("=").Append(jen.Id("nicks"), jen.Id("v").Dot("nick").Call()),
+				jen.Add(recv).Op("&^=").Id("v"),
+			),
+		),
+		jen.Line(),
+		jen.If(jen.Add(recv).Op("!=").Lit(0)).Block(
+			jen.Id("nicks").Op("=").Append(
+				jen.Id("nicks"),
+				jen.Qual("fmt", "Sprintf").Call(jen.Lit(goName+"(%d)"), jen.Uint().Call(recv)),
+			),
+		),
+		jen.Line(),
+		jen.Return(jen.Qual("strings", "Join").Call(jen.Id("nicks"), jen.Lit("|"))),
+	)
+	s.Line()
+
+	return s
+}
+
+// GenIsValid generates the IsValid method.
+func (e Enum) GenIsValid() *jen.Statement {
+	var goName = e.GoName()
+	var recv = jen.Id(firstChar(e.Name))
+
+	s := GenCommentReflowLines("IsValid", "returns true if "+firstChar(e.Name)+" is a known "+goName+".")
+	s.Func().Params(recv.Clone().Id(goName)).Id("IsValid").Params().Bool()
+
+	if e.flags {
+		var all = jen.Lit(0)
+		for _, member := range e.Members {
+			all.Op("|").Id(e.MemberGoName(member))
+		}
+
+		s.Block(jen.Return(jen.Add(recv).Op("&^").Parens(all).Op("==").Lit(0)))
+		s.Line()
+		return s
+	}
+
+	s.Block(jen.Return(recv.Clone().Dot("nick").Call().Op("!=").Lit("")))
+	s.Line()
+	return s
+}
+
+// GenTextMarshalers generates MarshalText and UnmarshalText, which allow enums
+// to be used in configuration files.
+func (e Enum) GenTextMarshalers() *jen.Statement {
+	var goName = e.GoName()
+	var recv = jen.Id(firstChar(e.Name))
+
+	s := GenCommentReflowLines("MarshalText", "implements encoding.TextMarshaler.")
+	s.Func().Params(recv.Clone().Id(goName)).Id("MarshalText").Params().Params(jen.Index().Byte(), jen.Error()).Block(
+		jen.If(jen.Op("!").Add(recv).Dot("IsValid").Call()).Block(
+			jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(
+				jen.Lit("invalid "+goName+" %d"), jen.Int().Call(recv),
+			)),
+		),
+		jen.Return(jen.Index().Byte().Call(recv.Clone().Dot("String").Call()), jen.Nil()),
+	)
+	s.Line()
+	s.Line()
+
+	var text = jen.Id("text")
+	var parse = jen.Id(e.parseFnName())
+	var unknown = jen.Qual("fmt", "Errorf").Call(jen.Lit("unknown "+goName+" %q"), jen.Id("nick"))
+
+	s.Add(GenCommentReflowLines("UnmarshalText", "implements encoding.TextUnmarshaler."))
+	s.Func().Params(recv.Clone().Op("*").Id(goName)).Id("UnmarshalText").Params(text.Clone().Index().Byte()).Error()
+
+	if !e.flags {
+		s.Block(
+			jen.Id("nick").Op(":=").String().Call(text),
+			jen.List(jen.Id("v"), jen.Id("ok")).Op(":=").Add(parse).Call(jen.Id("nick")),
+			jen.If(jen.Op("!").Id("ok")).Block(jen.Return(unknown)),
+			jen.Op("*").Add(recv).Op("=").Id("v"),
+			jen.Return(jen.Nil()),
+		)
+		s.Line()
+		return s
+	}
+
+	s.Block(
+		// An empty text is what MarshalText returns for no flags.
+		jen.If(jen.Len(text).Op("==").Lit(0)).Block(
+			jen.Op("*").Add(recv).Op("=").Lit(0),
+			jen.Return(jen.Nil()),
+		),
+		jen.Line(),
+		jen.Var().Id("flags").Id(goName),
+		jen.For(jen.List(jen.Id("_"), jen.Id("nick")).Op(":=").Range().Qual("strings", "Split").Call(
+			jen.String().Call(text), jen.Lit("|"),
+		)).Block(
+			jen.List(jen.Id("v"), jen.Id("ok")).Op(":=").Add(parse).Call(
+				jen.Qual("strings", "TrimSpace").Call(jen.Id("nick")),
+			),
+			jen.If(jen.Op("!").Id("ok")).Block(jen.Return(unknown)),
+			jen.Id("flags").Op("|=").Id("v"),
+		),
+		jen.Op("*").Add(recv).Op("=").Id("flags"),
+		jen.Return(jen.Nil()),
+	)
+	s.Line()
+
+	return s
+}
+
+// GenToGValue generates a method that converts the enum to a GValue of its own
+// GType. The value can then be given to gextras.SetPropertyValue.
+func (e Enum) GenToGValue() *jen.Statement {
+	var goName = e.GoName()
+	var recv = jen.Id(firstChar(e.Name))
+
+	var setter = jen.Qual("C", "g_value_set_enum")
+	var cast = jen.Qual("C", "gint")
+	if e.flags {
+		setter = jen.Qual("C", "g_value_set_flags")
+		cast = jen.Qual("C", "guint")
+	}
+
+	s := GenCommentReflowLines("ToGValue", "converts "+firstChar(e.Name)+
+		" to a GValue of its GType. The returned value can be given to"+
+		" gextras.SetPropertyValue.")
+	s.Func().Params(recv.Clone().Id(goName)).Id("ToGValue").Params().Params(
+		jen.Op("*").Qual("github.com/gotk3/gotk3/glib", "Value"), jen.Error(),
+	).Block(
+		jen.List(jen.Id("v"), jen.Err()).Op(":=").Qual("github.com/gotk3/gotk3/glib", "ValueInit").Call(
+			jen.Qual("github.com/gotk3/gotk3/glib", "Type").Call(jen.Qual("C", e.GLibGetType).Call()),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.Line(),
+		setter.Call(
+			jen.Parens(jen.Op("*").Qual("C", "GValue")).Call(
+				jen.Qual("unsafe", "Pointer").Call(jen.Id("v").Dot("Native").Call()),
+			),
+			cast.Call(recv),
+		),
+		jen.Return(jen.Id("v"), jen.Nil()),
+	)
+	s.Line()
+
+	return s
+}
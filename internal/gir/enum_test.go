@@ -0,0 +1,179 @@
+package gir
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestEnumGenTypeVersion(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	tests := []struct {
+		name string
+		enum Enum
+		want string
+	}{{
+		name: "documented",
+		enum: Enum{Name: "mode", Version: "1.2", Doc: &Doc{String: "The mode."}},
+		want: "// Mode the mode.\n//\n// Since: 1.2\ntype Mode int",
+	}, {
+		name: "undocumented",
+		enum: Enum{Name: "mode", Version: "1.2"},
+		want: "// Since: 1.2\ntype Mode int",
+	}, {
+		name: "unversioned",
+		enum: Enum{Name: "mode"},
+		want: "type Mode int",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := strings.TrimSpace(test.enum.GenType().GoString())
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+// TestBitfieldTextRoundTrip builds the generated text marshalers of a bitfield
+// into a program and checks that every value survives a round trip.
+func TestBitfieldTextRoundTrip(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	withNamespace(t, testNamespace())
+
+	enum := Bitfield{Enum: Enum{
+		Name: "flags",
+		Members: []Member{
+			{Name: "a", Value: 1},
+			{Name: "b", Value: 2},
+		},
+	}}.AsEnum()
+
+	f := jen.NewFile("main")
+	f.Add(enum.GenType())
+	f.Add(enum.GenConsts())
+	f.Add(enum.GenValues())
+	f.Add(enum.GenNick())
+	f.Add(enum.GenParse())
+	f.Add(enum.GenString())
+	f.Add(enum.GenIsValid())
+	f.Add(enum.GenTextMarshalers())
+	f.Func().Id("main").Params().Block(
+		jen.For(jen.List(jen.Id("_"), jen.Id("v")).Op(":=").Range().Index().Id("Flags").Values(
+			jen.Lit(0), jen.Id("FlagsA"), jen.Id("FlagsA").Op("|").Id("FlagsB"),
+		)).Block(
+			jen.List(jen.Id("text"), jen.Err()).Op(":=").Id("v").Dot("MarshalText").Call(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Panic(jen.Err())),
+			jen.Var().Id("got").Id("Flags"),
+			jen.If(jen.Err().Op(":=").Id("got").Dot("UnmarshalText").Call(jen.Id("text")), jen.Err().Op("!=").Nil()).Block(
+				jen.Panic(jen.Err()),
+			),
+			jen.If(jen.Id("got").Op("!=").Id("v")).Block(
+				jen.Panic(jen.Qual("fmt", "Sprint").Call(jen.Id("got"), jen.Lit(" != "), jen.Id("v"))),
+			),
+		),
+	)
+
+	dir := t.TempDir()
+	if err := f.Save(filepath.Join(dir, "main.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(goBin, "run", "main.go")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=off")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code failed: %v\n%s", err, out)
+	}
+}
+
+func TestMemberDocLink(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Enums = []Enum{{
+		Name:  "checker_error",
+		CType: "GspellCheckerError",
+		Members: []Member{
+			{Name: "dictionary", CIdentifier: "GSPELL_CHECKER_ERROR_DICTIONARY"},
+		},
+	}}
+	withNamespace(t, namespace)
+
+	got := gtkdocConverter{}.inline("Fails with %GSPELL_CHECKER_ERROR_DICTIONARY or %NULL.")
+	want := "Fails with [CheckerErrorDictionary] or nil."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestEnumGenerateAll(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	oldManual := manualDecls
+	t.Cleanup(func() { manualDecls = oldManual })
+
+	members := []Member{{Name: "a", Value: 0}, {Name: "b", Value: 1}}
+
+	tests := []struct {
+		name    string
+		enum    Enum
+		manual  []string
+		want    []string
+		notWant []string
+	}{{
+		name: "with GType",
+		enum: Enum{Name: "mode", GLibGetType: "gspell_mode_get_type", Members: members},
+		want: []string{
+			"func marshalMode(", "func (m Mode) nick()", "func parseMode(",
+			"func (m Mode) ToGValue()", "C.gspell_mode_get_type()",
+		},
+	}, {
+		name:    "without GType",
+		enum:    Enum{Name: "mode", Members: members},
+		want:    []string{"func (m Mode) String()", "func (m Mode) nick()"},
+		notWant: []string{"marshalMode", "ToGValue", "C.()"},
+	}, {
+		name:    "manual parse",
+		enum:    Enum{Name: "mode", Members: members},
+		manual:  []string{"parseMode"},
+		want:    []string{"func (m Mode) nick()", "func (m Mode) String()", "func (m Mode) IsValid()"},
+		notWant: []string{"func parseMode("},
+	}, {
+		name:    "manual nick",
+		enum:    Enum{Name: "mode", Members: members},
+		manual:  []string{"Mode.nick"},
+		want:    []string{"func parseMode("},
+		notWant: []string{"func (m Mode) nick()"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manualDecls = map[string]bool{}
+			for _, name := range test.manual {
+				manualDecls[name] = true
+			}
+
+			got := test.enum.GenerateAll().GoString()
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%q is missing:\n%s", want, got)
+				}
+			}
+			for _, notWant := range test.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("%q is generated:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package gir
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// ExternalNamespace is a namespace that is generated into another Go package.
+// Types of the namespace are referenced through that package.
+type ExternalNamespace struct {
+	ImportPath string
+
+	classes    map[string]bool
+	records    map[string]bool
+	enums      map[string]bool
+	interfaces map[string]bool // true if the interface requires a widget
+}
+
+var externalNamespaces = map[string]ExternalNamespace{}
+
+// AddExternal registers the namespace as generated into the package with the
+// given import path, so that other namespaces can reference its types.
+func (n namespaceGenerator) AddExternal(importPath string) {
+	var ext = ExternalNamespace{
+		ImportPath: importPath,
+		classes:    make(map[string]bool, len(n.Classes)),
+		records:    make(map[string]bool, len(n.Records)),
+		enums:      make(map[string]bool, len(n.Enums)+len(n.Bitfields)),
+		interfaces: make(map[string]bool, len(n.Interfaces)),
+	}
+
+	for _, class := range n.Classes {
+		ext.classes[class.Name] = true
+	}
+	for _, record := range n.Records {
+		if !record.IsIgnored() {
+			ext.records[record.Name] = true
+		}
+	}
+	for _, enum := range n.AllEnums() {
+		ext.enums[enum.Name] = true
+	}
+	for _, iface := range n.Interfaces {
+		ext.interfaces[iface.Name] = iface.RequiresWidget()
+	}
+
+	externalNamespaces[n.Name] = ext
+}
+
+// externalType returns the external namespace of the given gir type name and
+// the name of the type inside it. False is returned if the type doesn't belong
+// to another generated namespace.
+func externalType(typeName string) (ExternalNamespace, string, bool) {
+	parts := strings.SplitN(typeName, ".", 2)
+	if len(parts) != 2 || parts[0] == activeNamespace.Name {
+		return ExternalNamespace{}, "", false
+	}
+
+	ext, ok := externalNamespaces[parts[0]]
+	if !ok {
+		return ExternalNamespace{}, "", false
+	}
+
+	return ext, parts[1], true
+}
+
+// mapExternal maps the type to the Go type in the external package.
+func (t Type) mapExternal(ext ExternalNamespace, name string) *jen.Statement {
+	var goName = snakeToGo(true, name)
+
+	if t.IsPtr() && !ext.enums[name] {
+		return jen.Op("*").Qual(ext.ImportPath, goName)
+	}
+
+	return jen.Qual(ext.ImportPath, goName)
+}
+
+// genExternalCaster generates the conversion of a C value to the Go type in
+// the external package. Nil is returned if the type isn't known to need a
+// special conversion.
+func (t Type) genExternalCaster(ext ExternalNamespace, name string, tmpVar, value *jen.Statement) *jen.Statement {
+	var goName = snakeToGo(true, name)
+	var stmt = tmpVar.Clone().Op(":=")
+
+	switch requiresWidget, isIface := ext.interfaces[name]; {
+	case ext.classes[name]:
+		// Wrap functions are exported for this.
+		return stmt.Qual(ext.ImportPath, "Wrap"+goName).Call(
+			jen.Qual("unsafe", "Pointer").Call(value),
+		)
+
+	case ext.records[name]:
+		// The C types of different packages are different Go types, so cast
+		// through unsafe.Pointer.
+		return stmt.Parens(t.Type()).Call(jen.Qual("unsafe", "Pointer").Call(value))
+
+	case isIface && !requiresWidget:
+		if t.IsPtr() {
+			stmt.Op("&")
+		}
+		return stmt.Qual(ext.ImportPath, goName).Values(jen.Line().
+			Id("Object").Op(":").Add(genObjTake(value)).Op(",").
+			Line(),
+		)
+
+	case isIface:
+		fail("cannot convert widget interface %s from another package", t.Name)
+	}
+
+	return nil
+}
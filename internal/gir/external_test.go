@@ -0,0 +1,81 @@
+package gir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestExternalType(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	oldExternal := externalNamespaces
+	t.Cleanup(func() { externalNamespaces = oldExternal })
+	externalNamespaces = map[string]ExternalNamespace{}
+
+	namespaceGenerator{&Namespace{
+		Name:    "Other",
+		Classes: []Class{{Name: "Widget", CType: "OtherWidget"}},
+		Records: []Record{{Name: "Rect", CType: "OtherRect", GLibGetType: "other_rect_get_type"}},
+		Enums:   []Enum{{Name: "Mode", CType: "OtherMode"}},
+	}}.AddExternal("example.com/other")
+
+	tests := []struct {
+		name   string
+		typ    Type
+		goType string
+		caster string
+		enum   bool
+	}{{
+		name:   "class",
+		typ:    Type{Name: "Other.Widget", CType: "OtherWidget*"},
+		goType: "*other.Widget",
+		caster: "v := other.WrapWidget(unsafe.Pointer(p))",
+	}, {
+		name:   "record",
+		typ:    Type{Name: "Other.Rect", CType: "OtherRect*"},
+		goType: "*other.Rect",
+		caster: "v := (*other.Rect)(unsafe.Pointer(p))",
+	}, {
+		name:   "enum",
+		typ:    Type{Name: "Other.Mode", CType: "OtherMode"},
+		goType: "other.Mode",
+		enum:   true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ext, name, ok := externalType(test.typ.Name)
+			if !ok {
+				t.Fatal("the type isn't external")
+			}
+			if ext.ImportPath != "example.com/other" {
+				t.Errorf("got import path %q", ext.ImportPath)
+			}
+
+			if got := test.typ.Type().GoString(); got != test.goType {
+				t.Errorf("got Go type %q, want %q", got, test.goType)
+			}
+			if got := test.typ.IsEnum(); got != test.enum {
+				t.Errorf("got IsEnum %v, want %v", got, test.enum)
+			}
+
+			caster := test.typ.genExternalCaster(ext, name, jen.Id("v"), jen.Id("p"))
+			switch {
+			case test.caster == "" && caster != nil:
+				t.Errorf("got caster %q, want none", caster.GoString())
+			case test.caster != "" && caster == nil:
+				t.Errorf("got no caster, want %q", test.caster)
+			case caster != nil && !strings.Contains(caster.GoString(), test.caster):
+				t.Errorf("got caster %q, want %q", caster.GoString(), test.caster)
+			}
+		})
+	}
+
+	for _, name := range []string{"Unknown.Widget", "Gspell.Checker", "Widget"} {
+		if _, _, ok := externalType(name); ok {
+			t.Errorf("%s was resolved as an external type", name)
+		}
+	}
+}
@@ -18,9 +18,7 @@ func (f Function) GoName() string {
 
 func (f Function) GenFunc() *jen.Statement {
 	var stmt = new(jen.Statement)
-	if f.Doc != nil {
-		stmt.Add(f.Doc.GenGoComments("", f.GoName()))
-	}
+	stmt.Add(f.GenGoDoc(0, "", f.GoName()))
 
 	stmt.Func().Id(f.GoName())
 
@@ -56,11 +54,18 @@ func (f Function) GenFunc() *jen.Statement {
 	// Generate the value type converters in the function body.
 	stmt.BlockFunc(func(g *jen.Group) {
 		for i, param := range parm {
-			if arg, hasArgument := args[param.Name]; hasArgument {
-				var valueVar = jen.Id(fmt.Sprintf("v%d", i+1))
-				cargs[param.Name] = valueVar
+			var valueVar = jen.Id(fmt.Sprintf("v%d", i+1))
 
+			switch arg, hasArgument := args[param.Name]; {
+			case hasArgument:
+				cargs[param.Name] = valueVar
 				g.Add(param.GenValueCall(arg, valueVar))
+
+			case param.IsUserData():
+				if callback := f.UserDataParameter(i); callback != nil {
+					cargs[param.Name] = valueVar
+					g.Add(f.CallbackGenUserData(i, args[callback.Name], nil, valueVar))
+				}
 			}
 		}
 
@@ -70,19 +75,11 @@ func (f Function) GenFunc() *jen.Statement {
 
 		g.Add(f.ReturnValue.GenReturnFunc(
 			jen.Qual("C", f.CIdentifier).ParamsFunc(func(g *jen.Group) {
-				for i, param := range parm {
+				for _, param := range parm {
 					switch arg, hasCArgument := cargs[param.Name]; {
 					case hasCArgument:
 						g.Add(arg)
 
-					// Treat UserData and UserDataFreeFunc specially.
-					case param.IsUserData():
-						if callback := f.UserDataParameter(i); callback != nil {
-							g.Add(CallbackGenAssign(args[callback.Name]))
-						} else {
-							g.Add(param.Type.ZeroValue())
-						}
-
 					case param.IsUserDataFreeFunc():
 						g.Add(CallbackGenDelete())
 
@@ -3,7 +3,8 @@ package gir
 
 import (
 	"encoding/xml"
-	"log"
+	"fmt"
+	"path"
 	"regexp"
 	"strings"
 	"unicode"
@@ -26,6 +27,20 @@ var (
 	)
 )
 
+// RuntimePath is the import path of the runtime support package that the
+// generated code imports. The callback registry is in its callback subpackage.
+var RuntimePath = "github.com/diamondburned/gspell/gextras"
+
+// runtimeVersion is the version of the runtime support package that the
+// generated code requires. See gextras.SupportPackageIsVersion2.
+const runtimeVersion = 2
+
+// CallbackImportPath returns the import path of the callback package used by
+// the generated code.
+func CallbackImportPath() string {
+	return path.Join(RuntimePath, "callback")
+}
+
 // GoNamer is the interface for structs that can output idiomatic Go type names.
 type GoNamer interface {
 	GoName() string
@@ -47,18 +62,19 @@ func snakeToGo(pascal bool, snakeString string) string {
 
 func NewGotk3Generator(name string) *jen.File {
 	f := jen.NewFile(name)
+	f.HeaderComment(generatedHeader)
 	f.ImportName("github.com/gotk3/gotk3/gtk", "gtk")
 	f.ImportName("github.com/gotk3/gotk3/gdk", "gdk")
 	f.ImportName("github.com/gotk3/gotk3/glib", "glib")
 	f.ImportName("github.com/gotk3/gotk3/pango", "pango")
 	f.ImportName("github.com/gotk3/gotk3/cairo", "cairo")
-	f.ImportName("github.com/diamondburned/gspell/internal/callback", "callback")
-	f.CgoPreamble("#cgo pkg-config: gspell-1 gtk+-3.0 glib-2.0 gio-2.0 glib-2.0 gobject-2.0")
-	f.CgoPreamble("#include <gspell/gspell.h>")
-	f.CgoPreamble("#include <gtk/gtk.h>")
-	f.CgoPreamble("#include <gio/gio.h>")
-	f.CgoPreamble("#include <glib.h>")
-	f.CgoPreamble("#include <glib-object.h>")
+	f.ImportName(RuntimePath, "gextras")
+	f.ImportName(CallbackImportPath(), "callback")
+
+	f.Comment("This is a compile-time assertion that the imported gextras package is")
+	f.Comment("compatible with the generated code.")
+	f.Const().Id("_").Op("=").Qual(RuntimePath, fmt.Sprintf("SupportPackageIsVersion%d", runtimeVersion))
+	f.Line()
 
 	f.Comment("objector is used internally for other interfaces.")
 	f.Type().Id("objector").Interface(
@@ -119,6 +135,12 @@ type TransferOwnership struct {
 	TransferOwnership *string `xml:"transfer-ownership,attr"`
 }
 
+// IsTransferFull returns true if the ownership of the whole value is
+// transferred.
+func (t TransferOwnership) IsTransferFull() bool {
+	return t.TransferOwnership != nil && *t.TransferOwnership == "full"
+}
+
 // EmbeddedFieldCheck checks if the given goType embeds the required
 // containsType.
 func EmbeddedFieldCheck(goType, containsType string) bool {
@@ -137,7 +159,7 @@ func EmbeddedFieldCheck(goType, containsType string) bool {
 func EmbeddedField(goType string) string {
 	var t = EmbeddedFieldNoPanic(goType)
 	if t == "" {
-		log.Panicln("Unknown type:", goType)
+		fail("unknown embedded type %s", goType)
 	}
 	return t
 }
@@ -205,8 +227,12 @@ func EmbeddedFieldNoPanic(goType string) string {
 	return ""
 }
 
+func MarshalerFnName(typeName string) string {
+	return "marshal" + typeName
+}
+
 func GenMarshalerFnName(typeName string) *jen.Statement {
-	return jen.Id("marshal" + typeName)
+	return jen.Id(MarshalerFnName(typeName))
 }
 
 // GenMarshalerFn generates the marshal function's surroundings. The generated
@@ -254,7 +280,7 @@ func resolveWrapValueField(childType, fieldN string, implements ...Implements) *
 	if iface := activeNamespace.FindInterface(childType); iface != nil {
 		// TODO: confirm this is not needed.
 		if len(implements) > 0 {
-			log.Panicf("Interface %s shouldn't have implements\n", iface.Name)
+			fail("interface %s shouldn't have implements", iface.Name)
 		}
 
 		return GenInterfaceWrapper(iface.GoName(), iface.RequiresWidget())
@@ -0,0 +1,295 @@
+package gir
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+// docBlockKind is the kind of a block inside a gtk-doc comment.
+type docBlockKind uint8
+
+const (
+	docParagraph docBlockKind = iota
+	docHeading
+	docList
+	docCode
+)
+
+// docBlock is a single block of a gtk-doc comment. Paragraphs and headings
+// store their raw lines in Lines, lists store one entry per item, and code
+// blocks store their lines verbatim.
+type docBlock struct {
+	Kind  docBlockKind
+	Lines []string
+}
+
+var (
+	gtkdocListItemRegex = regexp.MustCompile(`^\s*[-*] +`)
+	gtkdocHeadingRegex  = regexp.MustCompile(`^#+ +`)
+	gtkdocLangCmtRegex  = regexp.MustCompile(`^\s*<!--.*?-->`)
+	gtkdocEntities      = strings.NewReplacer(
+		"&lt;", "<",
+		"&gt;", ">",
+		"&quot;", `"`,
+		"&apos;", "'",
+		"&amp;", "&",
+	)
+)
+
+// parseGtkDoc splits the given gtk-doc markup into blocks. Code blocks are
+// kept verbatim, while paragraphs, headings and list items are left for the
+// inline converter.
+func parseGtkDoc(doc string) []docBlock {
+	var blocks []docBlock
+	var current *docBlock
+
+	flush := func() {
+		if current != nil && len(current.Lines) > 0 {
+			blocks = append(blocks, *current)
+		}
+		current = nil
+	}
+
+	lines := strings.Split(gtkdocEntities.Replace(doc), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trim := strings.TrimSpace(line)
+
+		switch {
+		case trim == "":
+			flush()
+
+		case strings.HasPrefix(trim, "|["):
+			flush()
+
+			code := docBlock{Kind: docCode}
+
+			// Anything trailing the opening marker that isn't a language hint
+			// is part of the code.
+			rest := gtkdocLangCmtRegex.ReplaceAllString(trim[2:], "")
+			if end := strings.Index(rest, "]|"); end > -1 {
+				code.Lines = append(code.Lines, rest[:end])
+				blocks = append(blocks, code.trimCode())
+				continue
+			}
+			if strings.TrimSpace(rest) != "" {
+				code.Lines = append(code.Lines, rest)
+			}
+
+			for i++; i < len(lines); i++ {
+				if end := strings.Index(lines[i], "]|"); end > -1 {
+					code.Lines = append(code.Lines, lines[i][:end])
+					break
+				}
+				code.Lines = append(code.Lines, lines[i])
+			}
+
+			blocks = append(blocks, code.trimCode())
+
+		case gtkdocHeadingRegex.MatchString(trim):
+			flush()
+			blocks = append(blocks, docBlock{
+				Kind:  docHeading,
+				Lines: []string{gtkdocHeadingRegex.ReplaceAllString(trim, "")},
+			})
+
+		case gtkdocListItemRegex.MatchString(line):
+			if current == nil || current.Kind != docList {
+				flush()
+				current = &docBlock{Kind: docList}
+			}
+			current.Lines = append(current.Lines, gtkdocListItemRegex.ReplaceAllString(line, ""))
+
+		case current != nil && current.Kind == docList:
+			// Continuation of the last list item.
+			last := len(current.Lines) - 1
+			current.Lines[last] += " " + trim
+
+		default:
+			if current == nil {
+				current = &docBlock{Kind: docParagraph}
+			}
+			current.Lines = append(current.Lines, trim)
+		}
+	}
+
+	flush()
+	return blocks
+}
+
+// trimCode removes the leading and trailing empty lines as well as the common
+// indentation of a code block.
+func (b docBlock) trimCode() docBlock {
+	for len(b.Lines) > 0 && strings.TrimSpace(b.Lines[0]) == "" {
+		b.Lines = b.Lines[1:]
+	}
+	for len(b.Lines) > 0 && strings.TrimSpace(b.Lines[len(b.Lines)-1]) == "" {
+		b.Lines = b.Lines[:len(b.Lines)-1]
+	}
+
+	var indent = -1
+	for _, line := range b.Lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+
+	lines := make([]string, len(b.Lines))
+	for i, line := range b.Lines {
+		line = strings.TrimRight(line, " \t")
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		lines[i] = line
+	}
+	b.Lines = lines
+
+	return b
+}
+
+var (
+	gtkdocSymbolRegex    = regexp.MustCompile(`#([A-Z]\w*)(::?[a-z][\w-]*)?`)
+	gtkdocArgumentRegex  = regexp.MustCompile(`@\w+`)
+	gtkdocPrimitiveRegex = regexp.MustCompile(`%\w+`)
+	gtkdocFunctionRegex  = regexp.MustCompile(`\b\w+\(\)`)
+)
+
+// gtkdocConverter converts gtk-doc inline markup to Go doc syntax.
+type gtkdocConverter struct {
+	// selfName replaces @self.
+	selfName string
+}
+
+// inline converts the inline gtk-doc markup in the given text.
+func (c gtkdocConverter) inline(text string) string {
+	// Convert type, property and signal references into doc links.
+	text = gtkdocSymbolRegex.ReplaceAllStringFunc(text, func(str string) string {
+		m := gtkdocSymbolRegex.FindStringSubmatch(str)
+
+		link, ok := activeNamespace.DocLink(m[1])
+		if !ok {
+			return link + m[2]
+		}
+
+		return "[" + link + "]" + m[2]
+	})
+
+	// Replace C functions with known ones in the namespace. Prepend a C prefix
+	// otherwise.
+	text = gtkdocFunctionRegex.ReplaceAllStringFunc(text, func(str string) string {
+		var fnName = strings.TrimSuffix(str, "()")
+
+		if link, ok := activeNamespace.FnDocLink(fnName); ok {
+			return "[" + link + "]"
+		}
+
+		return fmt.Sprintf("C.%s()", fnName)
+	})
+
+	// Replace @arguments with their Go names and @self with the receiver.
+	text = gtkdocArgumentRegex.ReplaceAllStringFunc(text, func(str string) string {
+		if str == "@self" && c.selfName != "" {
+			return c.selfName
+		}
+		return snakeToGo(false, str[1:])
+	})
+
+	// Replace C primitives with Go's, and enum members with links to their
+	// constants.
+	text = gtkdocPrimitiveRegex.ReplaceAllStringFunc(text, func(str string) string {
+		// [:1] trims the % away.
+		if link, ok := activeNamespace.MemberDocLink(str[1:]); ok {
+			return "[" + link + "]"
+		}
+
+		switch str = str[1:]; str {
+		case "NULL":
+			return "nil"
+		case "TRUE":
+			return "true"
+		case "FALSE":
+			return "false"
+		default:
+			return str
+		}
+	})
+
+	return text
+}
+
+// render renders the given blocks into Go doc comment lines, without the
+// comment prefix. The prefix is prepended to the first paragraph.
+func (c gtkdocConverter) render(blocks []docBlock, columns uint, prefix string) []string {
+	var lines []string
+
+	if prefix != "" && (len(blocks) == 0 || blocks[0].Kind != docParagraph) {
+		blocks = append([]docBlock{{Kind: docParagraph}}, blocks...)
+	}
+
+	for i, block := range blocks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		switch block.Kind {
+		case docParagraph:
+			paragraph := c.inline(strings.Join(block.Lines, " "))
+			if i == 0 && prefix != "" {
+				paragraph = strings.TrimSpace(prefix + " " + paragraph)
+			}
+			lines = append(lines, wrapLines(paragraph, columns, "")...)
+
+		case docHeading:
+			// Go headings must not end with punctuation.
+			heading := strings.TrimRight(c.inline(block.Lines[0]), ".:")
+			lines = append(lines, "# "+heading)
+
+		case docList:
+			for _, item := range block.Lines {
+				wrapped := wrapLines(c.inline(item), columns-4, "    ")
+				wrapped[0] = "  - " + strings.TrimPrefix(wrapped[0], "    ")
+				lines = append(lines, wrapped...)
+			}
+
+		case docCode:
+			for _, line := range block.Lines {
+				// Prefix code lines with "//\t" so that they're printed
+				// verbatim.
+				if line == "" {
+					lines = append(lines, "//")
+				} else {
+					lines = append(lines, "//\t"+line)
+				}
+			}
+		}
+	}
+
+	return lines
+}
+
+// wrapLines wraps the given text and returns the lines indented with indent.
+func wrapLines(text string, columns uint, indent string) []string {
+	lines := strings.Split(wordwrap.WrapString(text, columns), "\n")
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+	return lines
+}
+
+// genCommentLines generates the comment statement from the rendered lines.
+func genCommentLines(lines []string) *jen.Statement {
+	var stmt = new(jen.Statement)
+	for _, line := range lines {
+		stmt.Comment(line).Line()
+	}
+	return stmt
+}
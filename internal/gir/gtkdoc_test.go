@@ -0,0 +1,166 @@
+package gir
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGtkDoc(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []docBlock
+	}{{
+		name: "paragraphs",
+		doc:  "First line\n  second line.\n\nNext &lt;b&gt; &amp; c.",
+		want: []docBlock{
+			{docParagraph, []string{"First line", "second line."}},
+			{docParagraph, []string{"Next <b> & c."}},
+		},
+	}, {
+		name: "heading",
+		doc:  "# Example\nText.",
+		want: []docBlock{
+			{docHeading, []string{"Example"}},
+			{docParagraph, []string{"Text."}},
+		},
+	}, {
+		name: "list",
+		doc:  "Items:\n- one\n  continued\n* two\n\nAfter.",
+		want: []docBlock{
+			{docParagraph, []string{"Items:"}},
+			{docList, []string{"one continued", "two"}},
+			{docParagraph, []string{"After."}},
+		},
+	}, {
+		name: "code block with language",
+		doc:  "Example:\n|[<!-- language=\"C\" -->\n  foo ();\n    bar ();\n]|\nAfter.",
+		want: []docBlock{
+			{docParagraph, []string{"Example:"}},
+			{docCode, []string{"foo ();", "  bar ();"}},
+			{docParagraph, []string{"After."}},
+		},
+	}, {
+		name: "single line code block",
+		doc:  "|[ a &lt; b ]|",
+		want: []docBlock{
+			{docCode, []string{"a < b"}},
+		},
+	}, {
+		name: "unterminated code block",
+		doc:  "|[\ncode",
+		want: []docBlock{
+			{docCode, []string{"code"}},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseGtkDoc(test.doc)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got  %#v\nwant %#v", got, test.want)
+			}
+		})
+	}
+}
+
+// withNamespace sets the active namespace for the duration of the test.
+func withNamespace(t *testing.T, namespace *Namespace) {
+	old := activeNamespace
+	activeNamespace = namespaceGenerator{namespace}
+	t.Cleanup(func() { activeNamespace = old })
+}
+
+func testNamespace() *Namespace {
+	return &Namespace{
+		Name:               "Gspell",
+		IdentifierPrefixes: "Gspell",
+		SymbolPrefixes:     "gspell",
+		Classes: []Class{{
+			Name:  "Checker",
+			CType: "GspellChecker",
+		}},
+		Functions: []Function{{
+			CallableAttrs: CallableAttrs{
+				Name:        "language_get_default",
+				CIdentifier: "gspell_language_get_default",
+			},
+		}},
+	}
+}
+
+func TestGtkDocInline(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"See #GspellChecker.", "See [Checker]."},
+		{"The #GspellChecker:language property.", "The [Checker]:language property."},
+		{"An unknown #GspellThing.", "An unknown Thing."},
+		{"A #GtkTextView.", "A [gtk.TextView]."},
+		{"Call gspell_language_get_default().", "Call [LanguageGetDefault]."},
+		{"Call g_free().", "Call C.g_free()."},
+		{"Set @word_length or @self.", "Set wordLength or c."},
+		{"Returns %NULL, %TRUE or %FALSE.", "Returns nil, true or false."},
+	}
+
+	c := gtkdocConverter{selfName: "c"}
+
+	for _, test := range tests {
+		if got := c.inline(test.in); got != test.want {
+			t.Errorf("inline(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestGtkDocRender(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	doc := "checks a word.\n\n" +
+		"# Notes:\n" +
+		"- a list item that is long enough to be wrapped onto the next line\n" +
+		"|[\nfoo ();\n\nbar ();\n]|"
+
+	want := []string{
+		"CheckWord checks a word.",
+		"",
+		"# Notes",
+		"",
+		"  - a list item that is long enough to",
+		"    be wrapped onto the next line",
+		"",
+		"//\tfoo ();",
+		"//",
+		"//\tbar ();",
+	}
+
+	got := gtkdocConverter{}.render(parseGtkDoc(doc), 40, "CheckWord")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWrapLines(t *testing.T) {
+	tests := []struct {
+		text    string
+		columns uint
+		indent  string
+		want    []string
+	}{
+		{"short", 10, "", []string{"short"}},
+		{"one two three", 7, "", []string{"one two", "three"}},
+		{"one two three", 7, "  ", []string{"  one two", "  three"}},
+		{"unbreakablewordislong", 5, "", []string{"unbreakablewordislong"}},
+	}
+
+	for _, test := range tests {
+		got := wrapLines(test.text, test.columns, test.indent)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("wrapLines(%q, %d, %q) = %q, want %q",
+				test.text, test.columns, test.indent, got, test.want)
+		}
+	}
+}
@@ -67,6 +67,10 @@ type Interface struct {
 	Functions     []Function     `xml:"http://www.gtk.org/introspection/core/1.0 function"`
 	Methods       []Method       `xml:"http://www.gtk.org/introspection/core/1.0 method"` // translated to Go fns
 	Prerequisites []Prerequisite `xml:"http://www.gtk.org/introspection/core/1.0 prerequisite"`
+	Properties    []Property     `xml:"http://www.gtk.org/introspection/core/1.0 property"`
+	Signals       []Signal       `xml:"http://www.gtk.org/introspection/glib/1.0 signal"`
+
+	Doc *Doc
 
 	// Constructor    *Constructor `xml:"http://www.gtk.org/introspection/core/1.0 constructor"`
 	// Implementses   []Implements    `xml:"http://www.gtk.org/introspection/core/1.0 implements"`
@@ -82,7 +86,7 @@ func (i Interface) GenerateAll() *jen.Statement {
 	s.Line()
 	s.Add(i.GenType())
 	s.Line()
-	s.Add(i.GenNative())
+	s.Add(genUnlessManual(i.GoName(), []string{"native"}, i.GenNative))
 	s.Line()
 	s.Add(i.GenMethods())
 	return s
@@ -129,11 +133,13 @@ func (i Interface) GenMethods() *jen.Statement {
 	var stmt = new(jen.Statement)
 
 	for _, m := range i.Methods {
-		if m.IsIgnored() {
+		if m.IsIgnored() || IsManual(name, m.GoName()) {
 			continue
 		}
 
-		stmt.Add(m.GenFunc(name))
+		stmt.Add(genSymbol(symbolPath(i.Name, m.Name), m.Doc, func() *jen.Statement {
+			return m.GenFunc(name)
+		}))
 		stmt.Line()
 	}
 
@@ -186,15 +192,15 @@ func (i Interface) GenInterface() *jen.Statement {
 		methods = append(methods, jen.Id("objector"))
 	}
 
+	// Hand-written methods still belong to the interface, since the struct
+	// implements them.
 	for _, m := range i.Methods {
 		if m.IsIgnored() {
 			continue
 		}
 
 		var stmt = new(jen.Statement)
-		if m.Doc != nil {
-			stmt.Add(m.Doc.GenGoComments(name, m.GoName()))
-		}
+		stmt.Add(m.GenGoDoc(0, name, m.GoName()))
 
 		var parm = []Parameter{}
 		if m.Parameters != nil {
@@ -0,0 +1,46 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestInterfaceManualMethods(t *testing.T) {
+	withNamespace(t, testNamespace())
+
+	oldManual := manualDecls
+	t.Cleanup(func() { manualDecls = oldManual })
+
+	iface := Interface{
+		Name:  "LanguageChooser",
+		CType: "GspellLanguageChooser",
+		Methods: []Method{{
+			Name:        "reset",
+			CIdentifier: "gspell_language_chooser_reset",
+			CallableAttrs: CallableAttrs{
+				Parameters: &Parameters{
+					InstanceParameter: &InstanceParameter{
+						ParameterAttrs: ParameterAttrs{
+							Name: "self",
+							Type: Type{Name: "LanguageChooser", CType: "GspellLanguageChooser*"},
+						},
+					},
+				},
+			},
+		}},
+	}
+
+	manualDecls = map[string]bool{
+		iface.GoName() + ".Reset":        true,
+		iface.InterfaceName() + ".Reset": true,
+	}
+
+	if methods := fmt.Sprintf("%#v", iface.GenMethods()); strings.Contains(methods, "Reset") {
+		t.Errorf("the hand-written method is generated:\n%s", methods)
+	}
+
+	if decl := fmt.Sprintf("%#v", iface.GenInterface()); !strings.Contains(decl, "Reset()") {
+		t.Errorf("the interface lacks the hand-written method:\n%s", decl)
+	}
+}
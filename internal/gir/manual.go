@@ -0,0 +1,117 @@
+package gir
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// manualDecls contains the functions and methods declared in hand-written
+// files of the target package. Functions are keyed by their names and methods
+// by "Type.Method".
+var manualDecls = map[string]bool{}
+
+// generatedFileRegex matches the comment that marks generated Go files.
+var generatedFileRegex = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// ScanManualDecls scans the hand-written Go files inside dir for functions and
+// methods, which are then skipped by the generator. Test files, generated files
+// and the given excluded files are ignored. Since the hand-written functions
+// have the same names, generated code calls them instead.
+func ScanManualDecls(dir string, exclude ...string) error {
+	manualDecls = map[string]bool{}
+
+	var excluded = make(map[string]bool, len(exclude))
+	for _, path := range exclude {
+		excluded[filepath.Clean(path)] = true
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return errors.Wrap(err, "Failed to list Go files")
+	}
+
+	var fset = token.NewFileSet()
+
+	for _, path := range matches {
+		if excluded[filepath.Clean(path)] || strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return errors.Wrap(err, "Failed to parse hand-written file")
+		}
+
+		if isGeneratedFile(f) {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			var name = fn.Name.Name
+			if fn.Recv != nil && len(fn.Recv.List) > 0 {
+				name = recvTypeName(fn.Recv.List[0].Type) + "." + name
+			}
+
+			manualDecls[name] = true
+		}
+	}
+
+	return nil
+}
+
+func isGeneratedFile(f *ast.File) bool {
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			if generatedFileRegex.MatchString(comment.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func recvTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	default:
+		return ""
+	}
+}
+
+// IsManual returns true if any of the given functions is hand-written. The
+// receiver is the type name of methods, or empty for functions.
+func IsManual(recv string, names ...string) bool {
+	for _, name := range names {
+		if recv != "" {
+			name = recv + "." + name
+		}
+		if manualDecls[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// genUnlessManual calls gen unless any of the functions it generates is
+// hand-written, in which case an empty statement is returned.
+func genUnlessManual(recv string, names []string, gen func() *jen.Statement) *jen.Statement {
+	if IsManual(recv, names...) {
+		return new(jen.Statement)
+	}
+	return gen()
+}
@@ -17,11 +17,10 @@ type Method struct {
 func (m Method) GenFunc(parentType string) *jen.Statement {
 	i := firstChar(parentType)
 	p := jen.Id(i).Op("*").Id(parentType)
+	recv := jen.Id(i)
 
 	var stmt = new(jen.Statement)
-	if m.Doc != nil {
-		stmt.Add(m.Doc.GenGoComments(i, m.GoName()))
-	}
+	stmt.Add(m.GenGoDoc(0, i, m.GoName()))
 
 	stmt.Func().Params(p).Id(m.GoName())
 
@@ -56,11 +55,22 @@ func (m Method) GenFunc(parentType string) *jen.Statement {
 	// Generate the value type converters in the function body.
 	stmt.BlockFunc(func(g *jen.Group) {
 		for i, param := range parm {
-			if arg, hasArgument := args[param.Name]; hasArgument {
-				var valueVar = jen.Id(fmt.Sprintf("v%d", i+1))
-				cargs[param.Name] = valueVar
+			var valueVar = jen.Id(fmt.Sprintf("v%d", i+1))
 
+			switch arg, hasArgument := args[param.Name]; {
+			case hasArgument:
+				cargs[param.Name] = valueVar
 				g.Add(param.GenValueCall(arg, valueVar))
+
+			// Assign the callback of the user data, and tie it to the
+			// instance if C never deletes it.
+			case param.IsUserData():
+				if callback := m.UserDataParameter(i); callback != nil {
+					cargs[param.Name] = valueVar
+					g.Add(m.CallbackGenUserData(
+						i, args[callback.Name], recv.Clone().Dot("native").Call(), valueVar,
+					))
+				}
 			}
 		}
 
@@ -71,22 +81,14 @@ func (m Method) GenFunc(parentType string) *jen.Statement {
 		g.Add(m.ReturnValue.GenReturnFunc(
 			jen.Qual("C", m.CIdentifier).ParamsFunc(func(g *jen.Group) {
 				if m.HasInstanceParameter() {
-					g.Add(jen.Id(i).Op(".").Id("native").Call())
+					g.Add(recv.Clone().Op(".").Id("native").Call())
 				}
 
-				for i, param := range parm {
+				for _, param := range parm {
 					switch arg, hasCArgument := cargs[param.Name]; {
 					case hasCArgument:
 						g.Add(arg)
 
-					// Treat UserData and UserDataFreeFunc specially.
-					case param.IsUserData():
-						if callback := m.UserDataParameter(i); callback != nil {
-							g.Add(CallbackGenAssign(args[callback.Name]))
-						} else {
-							g.Add(param.Type.ZeroValue())
-						}
-
 					case param.IsUserDataFreeFunc():
 						g.Add(CallbackGenDelete())
 
@@ -0,0 +1,102 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMethodCallbackScope(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Callbacks = []Callback{{
+		CallableAttrs: CallableAttrs{Name: "WordFunc"},
+	}}
+	withNamespace(t, namespace)
+
+	method := func(scope string, destroy bool) Method {
+		params := []Parameter{
+			{ParameterAttrs: ParameterAttrs{
+				Name:  "word_func",
+				Scope: scope,
+				Type:  Type{Name: "WordFunc", CType: "GspellWordFunc"},
+			}},
+			{ParameterAttrs: ParameterAttrs{
+				Name: "user_data",
+				Type: Type{Name: "gpointer", CType: "gpointer"},
+			}},
+		}
+
+		if destroy {
+			params = append(params, Parameter{ParameterAttrs: ParameterAttrs{
+				Name: "destroy",
+				Type: Type{Name: "GLib.DestroyNotify", CType: "GDestroyNotify"},
+			}})
+		}
+
+		return Method{
+			Name:        "foreach_word",
+			CIdentifier: "gspell_checker_foreach_word",
+			CallableAttrs: CallableAttrs{
+				Parameters: &Parameters{
+					InstanceParameter: &InstanceParameter{
+						ParameterAttrs: ParameterAttrs{
+							Name: "checker",
+							Type: Type{Name: "Checker", CType: "GspellChecker*"},
+						},
+					},
+					Parameters: params,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		method  Method
+		want    []string
+		notWant []string
+	}{{
+		name:    "call",
+		method:  method("call", false),
+		want:    []string{"callback.Assign(wordFunc)", "defer callback.Delete(callback.Handle(v2))"},
+		notWant: []string{"AssignObject"},
+	}, {
+		name:    "default without notify",
+		method:  method("", false),
+		want:    []string{"defer callback.Delete(callback.Handle(v2))"},
+		notWant: []string{"AssignObject"},
+	}, {
+		name:    "async",
+		method:  method("async", false),
+		want:    []string{"callback.AssignOnce(wordFunc)"},
+		notWant: []string{"callback.Delete"},
+	}, {
+		name:    "notified",
+		method:  method("notified", true),
+		want:    []string{"callback.Assign(wordFunc)", "gspell_trampoline_delete"},
+		notWant: []string{"AssignObject", "callback.Delete"},
+	}, {
+		name:    "notified without notify",
+		method:  method("notified", false),
+		want:    []string{"callback.AssignObject(unsafe.Pointer(c.native()), wordFunc)"},
+		notWant: []string{"callback.Delete"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fmt.Sprintf("%#v", test.method.GenFunc("Checker"))
+
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("missing %q:\n%s", want, got)
+				}
+			}
+
+			for _, notWant := range test.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("unexpected %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 type Namespace struct {
 	XMLName            xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 namespace"`
+	Name               string   `xml:"name,attr"`
 	Version            string   `xml:"version,attr"`
 	SharedLibrary      string   `xml:"shared-library,attr"`
 	IdentifierPrefixes string   `xml:"http://www.gtk.org/introspection/c/1.0 identifier-prefixes,attr"`
@@ -18,6 +19,7 @@ type Namespace struct {
 	Classes     []Class      `xml:"http://www.gtk.org/introspection/core/1.0 class"`
 	Records     []Record     `xml:"http://www.gtk.org/introspection/core/1.0 record"`
 	Enums       []Enum       `xml:"http://www.gtk.org/introspection/core/1.0 enumeration"`
+	Bitfields   []Bitfield   `xml:"http://www.gtk.org/introspection/core/1.0 bitfield"`
 	Functions   []Function   `xml:"http://www.gtk.org/introspection/core/1.0 function"`
 	Callbacks   []Callback   `xml:"http://www.gtk.org/introspection/core/1.0 callback"`
 	Interfaces  []Interface  `xml:"http://www.gtk.org/introspection/core/1.0 interface"`
@@ -28,6 +30,16 @@ type namespaceGenerator struct {
 	*Namespace
 }
 
+// AllEnums returns both the enums and the bitfields of the namespace.
+func (n namespaceGenerator) AllEnums() []Enum {
+	var enums = make([]Enum, 0, len(n.Enums)+len(n.Bitfields))
+	enums = append(enums, n.Enums...)
+	for _, bitfield := range n.Bitfields {
+		enums = append(enums, bitfield.AsEnum())
+	}
+	return enums
+}
+
 // FnWithC searches the entire namespace for anything with the given C
 // identifier. The returned type mayy be Method, Constructor or Function.
 func (n namespaceGenerator) FnWithC(CIdentifier string) interface{} {
@@ -37,6 +49,22 @@ func (n namespaceGenerator) FnWithC(CIdentifier string) interface{} {
 		}
 	}
 
+	for _, iface := range n.Interfaces {
+		for _, method := range iface.Methods {
+			if method.CIdentifier == CIdentifier {
+				return method
+			}
+		}
+	}
+
+	for _, record := range n.Records {
+		for _, method := range record.Methods {
+			if method.CIdentifier == CIdentifier {
+				return method
+			}
+		}
+	}
+
 	for _, function := range n.Functions {
 		if function.CIdentifier == CIdentifier {
 			return function
@@ -46,6 +74,85 @@ func (n namespaceGenerator) FnWithC(CIdentifier string) interface{} {
 	return nil
 }
 
+// FnDocLink returns the Go doc link target for the given C function
+// identifier. False is returned if the function isn't generated.
+func (n namespaceGenerator) FnDocLink(CIdentifier string) (string, bool) {
+	switch fn := n.FnWithC(CIdentifier).(type) {
+	case Method:
+		if fn.IsIgnored() || !fn.Parameters.HasInstanceParameter() {
+			return "", false
+		}
+
+		var recv = fn.Parameters.InstanceParameter.Type.GoType()
+		return strings.TrimPrefix(recv, "*") + "." + fn.GoName(), true
+
+	case Constructor:
+		return fn.GoName(), !fn.IsIgnored()
+	case Function:
+		return fn.GoName(), !fn.IsIgnored()
+	}
+
+	return "", false
+}
+
+// MemberDocLink returns the Go doc link target for the enum or bitfield member
+// with the given C identifier. False is returned if there's no such member.
+func (n namespaceGenerator) MemberDocLink(CIdentifier string) (string, bool) {
+	for _, enum := range n.AllEnums() {
+		for _, member := range enum.Members {
+			if member.CIdentifier == CIdentifier {
+				return enum.MemberGoName(member), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// DocLink returns the Go doc link target for the given C type name. If the type
+// isn't known, then the name without the namespace prefix and false is
+// returned.
+func (n namespaceGenerator) DocLink(CType string) (string, bool) {
+	for _, class := range n.Classes {
+		if class.CType == CType {
+			return class.GoName(), true
+		}
+	}
+
+	for _, iface := range n.Interfaces {
+		if iface.CType == CType {
+			return iface.GoName(), true
+		}
+	}
+
+	for _, record := range n.Records {
+		if record.CType == CType && !record.IsIgnored() {
+			return record.GoName(), true
+		}
+	}
+
+	for _, enum := range n.AllEnums() {
+		if enum.CType == CType {
+			return enum.GoName(), true
+		}
+	}
+
+	for _, callback := range n.Callbacks {
+		if callback.CType == CType {
+			return callback.GoName(), true
+		}
+	}
+
+	// Link types from the packages that the generated file imports.
+	for _, prefix := range []string{"Gtk", "Gdk"} {
+		if strings.HasPrefix(CType, prefix) && len(CType) > len(prefix) {
+			return TypeMap(prefix + "." + CType[len(prefix):]).GoString(), true
+		}
+	}
+
+	return strings.TrimPrefix(CType, n.IdentifierPrefixes), false
+}
+
 func (n namespaceGenerator) FindInterface(ifaceName string) *Interface {
 	for _, iface := range n.Interfaces {
 		if iface.Name == ifaceName {
@@ -56,37 +163,67 @@ func (n namespaceGenerator) FindInterface(ifaceName string) *Interface {
 	return nil
 }
 
-func (n namespaceGenerator) GenerateToFile(f *jen.File) {
-	f.CgoPreamble(n.GenCallbackPreamble())
-	f.Add(n.GenerateAll())
-}
+// GenerateToFile generates the namespace into the given file, which includes
+// the C header with the given name. ErrAborted is returned if a symbol fails to
+// generate and KeepGoing is false; the reason is reported in Diagnostics.
+func (n namespaceGenerator) GenerateToFile(f *jen.File, headerName string) (err error) {
+	defer func() {
+		switch v := recover().(type) {
+		case nil:
+		case abortGeneration:
+			err = ErrAborted
+		case symbolFailure:
+			// Failed outside of any symbol, so nothing can be skipped.
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityError,
+				Path:     n.Name,
+				Message:  v.message,
+			})
+			err = ErrAborted
+		default:
+			panic(v)
+		}
+	}()
 
-func (n namespaceGenerator) GenCallbackPreamble() string {
-	var preambles = make([]string, 0, len(n.Callbacks))
-	for _, callback := range n.Callbacks {
-		preambles = append(preambles, fmt.Sprintf("// %s", callback.GenExternC()))
+	if packages, _ := CgoDirectives(); len(packages) > 0 {
+		f.CgoPreamble("#cgo pkg-config: " + strings.Join(packages, " "))
 	}
+	f.CgoPreamble(fmt.Sprintf("#include %q", headerName))
 
-	return strings.Join(preambles, "\n")
+	f.Add(n.GenerateAll())
+	return nil
 }
 
 func (n namespaceGenerator) GenerateAll() *jen.Statement {
+	// The symbols are generated before init, so that it doesn't register the
+	// marshalers of the ones that failed.
+	closuresUsed = false
+
+	symbols := new(jen.Statement)
+	symbols.Add(n.GenEnums())
+	symbols.Add(n.GenInterfaces())
+	symbols.Add(n.GenCallbacks())
+	symbols.Add(n.GenFunctions())
+	symbols.Add(n.GenClasses())
+	symbols.Add(n.GenRecords())
+
+	// The closure helpers are only known to be needed once all symbols are
+	// generated.
+	if closuresUsed {
+		symbols.Add(GenClosure())
+	}
+
 	f := new(jen.Statement)
 	f.Add(n.GenInit())
-	f.Add(n.GenEnums())
-	f.Add(n.GenInterfaces())
-	f.Add(n.GenCallbacks())
-	f.Add(n.GenFunctions())
-	f.Add(n.GenClasses())
-	f.Add(n.GenRecords())
+	f.Add(symbols)
 	return f
 }
 
 func (n namespaceGenerator) GenEnums() *jen.Statement {
 	var f = new(jen.Statement)
 
-	for _, enum := range n.Enums {
-		f.Add(enum.GenerateAll())
+	for _, enum := range n.AllEnums() {
+		f.Add(genSymbol(symbolPath(enum.Name), enum.Doc, enum.GenerateAll))
 		f.Line()
 	}
 
@@ -97,7 +234,7 @@ func (n namespaceGenerator) GenInterfaces() *jen.Statement {
 	var f = new(jen.Statement)
 
 	for _, iface := range n.Interfaces {
-		f.Add(iface.GenerateAll())
+		f.Add(genSymbol(symbolPath(iface.Name), iface.Doc, iface.GenerateAll))
 		f.Line()
 	}
 
@@ -105,12 +242,13 @@ func (n namespaceGenerator) GenInterfaces() *jen.Statement {
 }
 
 func (n namespaceGenerator) GenCallbacks() *jen.Statement {
-	var f = new(jen.Statement)
+	var f = GenCallbackDelete()
+	f.Line()
 
 	for _, callback := range n.Callbacks {
-		f.Add(callback.GenGoType())
-		f.Line()
-		f.Add(callback.GenGlobalGoFunction())
+		f.Add(genSymbol(symbolPath(callback.Name), callback.Doc, func() *jen.Statement {
+			return jen.Add(callback.GenGoType()).Line().Add(callback.GenGlobalGoFunction()).Line()
+		}))
 		f.Line()
 	}
 
@@ -121,11 +259,11 @@ func (n namespaceGenerator) GenFunctions() *jen.Statement {
 	var f = new(jen.Statement)
 
 	for _, function := range n.Functions {
-		if function.IsIgnored() {
+		if function.IsIgnored() || IsManual("", function.GoName()) {
 			continue
 		}
 
-		f.Add(function.GenFunc())
+		f.Add(genSymbol(symbolPath(function.Name), function.Doc, function.GenFunc))
 		f.Line()
 	}
 
@@ -136,7 +274,7 @@ func (n namespaceGenerator) GenClasses() *jen.Statement {
 	var f = new(jen.Statement)
 
 	for _, class := range n.Classes {
-		f.Add(class.GenerateAll())
+		f.Add(genSymbol(symbolPath(class.Name), class.Doc, class.GenerateAll))
 		f.Line()
 	}
 
@@ -150,7 +288,7 @@ func (n namespaceGenerator) GenRecords() *jen.Statement {
 		if record.IsIgnored() {
 			continue
 		}
-		f.Add(record.GenerateAll())
+		f.Add(genSymbol(symbolPath(record.Name), record.Doc, record.GenerateAll))
 		f.Line()
 	}
 
@@ -169,9 +307,18 @@ func (n namespaceGenerator) genMarshalers() *jen.Statement {
 	)
 }
 
+// genMarshalersList lists the marshalers of the generated types. The types
+// that failed to generate are skipped, since their marshalers don't exist, and
+// so are the enums without a GType.
 func (n namespaceGenerator) genMarshalersList(g *jen.Group) {
 	g.Comment("Enums")
-	for _, enum := range n.Enums {
+	for _, enum := range n.AllEnums() {
+		if enum.GLibGetType == "" {
+			continue
+		}
+		if _, failed := symbolFailed(symbolPath(enum.Name)); failed {
+			continue
+		}
 		g.Add(enum.GenMarshalerItem()).Op(",")
 	}
 
@@ -179,6 +326,9 @@ func (n namespaceGenerator) genMarshalersList(g *jen.Group) {
 
 	g.Comment("Objects/Classes")
 	for _, class := range n.Classes {
+		if _, failed := symbolFailed(symbolPath(class.Name)); failed {
+			continue
+		}
 		g.Add(class.GenMarshalerItem()).Op(",")
 	}
 
@@ -189,6 +339,9 @@ func (n namespaceGenerator) genMarshalersList(g *jen.Group) {
 		if record.IsIgnored() {
 			continue
 		}
+		if _, failed := symbolFailed(symbolPath(record.Name)); failed {
+			continue
+		}
 		g.Add(record.GenMarshalerItem()).Op(",")
 	}
 }
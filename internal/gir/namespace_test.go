@@ -0,0 +1,42 @@
+package gir
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dave/jennifer/jen"
+)
+
+func TestMarshalersSkipFailed(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Classes[0].GLibGetType = "gspell_checker_get_type"
+	namespace.Classes = append(namespace.Classes, Class{
+		Name:        "Broken",
+		CType:       "GspellBroken",
+		GLibGetType: "gspell_broken_get_type",
+	})
+	namespace.Enums = append(namespace.Enums, Enum{Name: "untyped", CType: "GspellUntyped"})
+	withNamespace(t, namespace)
+
+	oldDiagnostics := diagnostics
+	t.Cleanup(func() { diagnostics = oldDiagnostics })
+
+	diagnostics = []Diagnostic{{
+		Severity: SeverityError,
+		Path:     "Gspell.Broken",
+		Message:  "unknown native type",
+	}}
+
+	got := fmt.Sprintf("%#v", jen.Index().Id("T").BlockFunc(activeNamespace.genMarshalersList))
+
+	if !strings.Contains(got, "gspell_checker_get_type") {
+		t.Errorf("the marshaler of Checker is missing:\n%s", got)
+	}
+	if strings.Contains(got, "gspell_broken_get_type") {
+		t.Errorf("the marshaler of the failed Broken is registered:\n%s", got)
+	}
+	if strings.Contains(got, "marshalUntyped") || strings.Contains(got, "C.()") {
+		t.Errorf("the marshaler of Untyped, which has no GType, is registered:\n%s", got)
+	}
+}
@@ -0,0 +1,34 @@
+package gir
+
+import "encoding/xml"
+
+type Property struct {
+	XMLName       xml.Name `xml:"http://www.gtk.org/introspection/core/1.0 property"`
+	Name          string   `xml:"name,attr"`
+	Version       string   `xml:"version,attr"`
+	Readable      *bool    `xml:"readable,attr"`
+	Writable      bool     `xml:"writable,attr"`
+	Construct     bool     `xml:"construct,attr"`
+	ConstructOnly bool     `xml:"construct-only,attr"`
+	TransferOwnership
+
+	Type Type
+	Doc  *Doc
+}
+
+// IsReadable returns true if the property can be read. Properties are readable
+// unless the gir file says otherwise.
+func (p Property) IsReadable() bool {
+	return p.Readable == nil || *p.Readable
+}
+
+type Signal struct {
+	XMLName xml.Name `xml:"http://www.gtk.org/introspection/glib/1.0 signal"`
+	Name    string   `xml:"name,attr"`
+	When    string   `xml:"when,attr"`
+	Version string   `xml:"version,attr"`
+
+	Parameters  *Parameters
+	ReturnValue *ReturnValue `xml:"http://www.gtk.org/introspection/core/1.0 return-value"`
+	Doc         *Doc
+}
@@ -19,6 +19,8 @@ type Record struct {
 
 	Methods   []Method   `xml:"http://www.gtk.org/introspection/core/1.0 method"`
 	Functions []Function `xml:"http://www.gtk.org/introspection/core/1.0 function"`
+
+	Doc *Doc
 }
 
 func (r Record) IsIgnored() bool {
@@ -30,9 +32,9 @@ func (r Record) GenerateAll() *jen.Statement {
 	f := new(jen.Statement)
 	f.Add(r.GenType())
 	f.Line()
-	f.Add(r.GenMarshaler())
+	f.Add(genUnlessManual("", []string{MarshalerFnName(r.GoName())}, r.GenMarshaler))
 	f.Line()
-	f.Add(r.GenNative())
+	f.Add(genUnlessManual(r.GoName(), []string{"native", "Native"}, r.GenNative))
 	f.Line()
 	f.Add(r.GenMethods())
 	return f
@@ -103,11 +105,13 @@ func (r Record) GenNative() *jen.Statement {
 func (r Record) GenMethods() *jen.Statement {
 	var stmt = make(jen.Statement, 0, len(r.Methods)*3)
 	for _, method := range r.Methods {
-		if method.IsIgnored() {
+		if method.IsIgnored() || IsManual(r.Name, method.GoName()) {
 			continue
 		}
 
-		stmt.Add(method.GenFunc(r.Name))
+		stmt.Add(genSymbol(symbolPath(r.Name, method.Name), method.Doc, func() *jen.Statement {
+			return method.GenFunc(r.Name)
+		}))
 		stmt.Line()
 	}
 
@@ -0,0 +1,218 @@
+package gir
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ReportEntry is a single symbol in a coverage report.
+type ReportEntry struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	CIdentifier string `json:"c_identifier,omitempty"`
+	GoName      string `json:"go_name,omitempty"`
+	Generated   bool   `json:"generated"`
+	Manual      bool   `json:"manual,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Report is the coverage report of a namespace. It lists every symbol in the
+// namespace and whether it was generated, hand-written or skipped. Coverage
+// counts both generated and hand-written symbols.
+type Report struct {
+	Namespace string        `json:"namespace"`
+	Version   string        `json:"version"`
+	Total     int           `json:"total"`
+	Generated int           `json:"generated"`
+	Manual    int           `json:"manual"`
+	Coverage  float64       `json:"coverage"`
+	Entries   []ReportEntry `json:"entries"`
+}
+
+// Reasons for skipping symbols that aren't callables.
+const (
+	reasonNoGType     = "no GType"
+	reasonUnsupported = "not supported by the generator"
+)
+
+// Report creates a coverage report for the namespace. Hand-written symbols are
+// the ones found by ScanManualDecls, and failed symbols are the ones reported
+// in Diagnostics, so the namespace should be generated with KeepGoing first.
+func (n namespaceGenerator) Report() Report {
+	var r = Report{
+		Namespace: n.Name,
+		Version:   n.Version,
+	}
+
+	for _, class := range n.Classes {
+		r.add(ReportEntry{Kind: "class", Name: class.Name, GoName: class.GoName()})
+
+		for _, ctor := range class.Constructors {
+			r.addCallable("constructor", class.Name, ctor.Name, ctor.GoName(), ctor.CallableAttrs)
+		}
+		for _, method := range class.Methods {
+			r.addMethod(class.Name, class.Name, class.GoName(), method)
+		}
+		for _, function := range class.Functions {
+			r.addCallable("function", class.Name, function.Name, function.GoName(), function.CallableAttrs)
+		}
+
+		r.addProperties(class.Name, class.Properties)
+		r.addSignals(class.Name, class.Signals)
+	}
+
+	for _, iface := range n.Interfaces {
+		r.add(ReportEntry{Kind: "interface", Name: iface.Name, GoName: iface.GoName()})
+
+		for _, method := range iface.Methods {
+			r.addMethod(iface.Name, iface.GoName(), iface.GoName(), method)
+		}
+
+		r.addProperties(iface.Name, iface.Properties)
+		r.addSignals(iface.Name, iface.Signals)
+	}
+
+	for _, record := range n.Records {
+		var entry = ReportEntry{Kind: "record", Name: record.Name, GoName: record.GoName()}
+		if record.IsIgnored() {
+			entry.Reason = reasonNoGType
+			r.add(entry)
+			continue
+		}
+
+		r.add(entry)
+
+		for _, method := range record.Methods {
+			r.addMethod(record.Name, record.Name, record.GoName(), method)
+		}
+	}
+
+	for _, enum := range n.AllEnums() {
+		var kind = "enum"
+		if enum.IsFlags() {
+			kind = "bitfield"
+		}
+		r.add(ReportEntry{Kind: kind, Name: enum.Name, GoName: enum.GoName()})
+	}
+
+	for _, function := range n.Functions {
+		r.addCallable("function", "", function.Name, function.GoName(), function.CallableAttrs)
+	}
+
+	for _, callback := range n.Callbacks {
+		r.add(ReportEntry{Kind: "callback", Name: callback.Name, GoName: callback.GoName()})
+	}
+
+	if r.Total > 0 {
+		r.Coverage = float64(r.Generated+r.Manual) / float64(r.Total) * 100
+	}
+
+	return r
+}
+
+func (r *Report) add(entry ReportEntry) {
+	// A symbol is skipped along with its parent if the parent failed.
+	if entry.Reason == "" && !entry.Manual {
+		var path = r.Namespace
+		for _, name := range strings.FieldsFunc(entry.Name, isNameSeparator) {
+			path += "." + name
+
+			if d, ok := symbolFailed(path); ok {
+				entry.Reason = "failed: " + d.Message
+				break
+			}
+		}
+	}
+
+	entry.Generated = entry.Reason == "" && !entry.Manual
+
+	r.Total++
+	switch {
+	case entry.Generated:
+		r.Generated++
+	case entry.Manual:
+		r.Manual++
+	}
+
+	r.Entries = append(r.Entries, entry)
+}
+
+func isNameSeparator(r rune) bool {
+	return r == '.' || r == ':'
+}
+
+func (r *Report) addCallable(kind, parent, name, goName string, c CallableAttrs) {
+	if parent != "" {
+		name = parent + "." + name
+	}
+
+	var reason = c.IgnoreReason()
+
+	r.add(ReportEntry{
+		Kind:        kind,
+		Name:        name,
+		CIdentifier: c.CIdentifier,
+		GoName:      goName,
+		Manual:      reason == "" && IsManual("", goName),
+		Reason:      reason,
+	})
+}
+
+// addMethod adds a method. The receiver is the name that hand-written methods
+// are looked up with.
+func (r *Report) addMethod(parent, recv, parentGoName string, m Method) {
+	var reason = m.IgnoreReason()
+
+	r.add(ReportEntry{
+		Kind:        "method",
+		Name:        parent + "." + m.Name,
+		CIdentifier: m.CIdentifier,
+		GoName:      parentGoName + "." + m.GoName(),
+		Manual:      reason == "" && IsManual(recv, m.GoName()),
+		Reason:      reason,
+	})
+}
+
+func (r *Report) addProperties(parent string, props []Property) {
+	for _, prop := range props {
+		r.add(ReportEntry{
+			Kind:   "property",
+			Name:   parent + ":" + prop.Name,
+			Reason: reasonUnsupported,
+		})
+	}
+}
+
+func (r *Report) addSignals(parent string, signals []Signal) {
+	for _, signal := range signals {
+		r.add(ReportEntry{
+			Kind:   "signal",
+			Name:   parent + "::" + signal.Name,
+			Reason: reasonUnsupported,
+		})
+	}
+}
+
+// WriteText writes the report in a human-readable table.
+func (r Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "%s-%s: %d/%d symbols generated, %d hand-written (%.1f%%)\n\n",
+		r.Namespace, r.Version, r.Generated, r.Total, r.Manual, r.Coverage)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for _, entry := range r.Entries {
+		var status = "generated"
+		switch {
+		case entry.Manual:
+			status = "hand-written"
+		case !entry.Generated:
+			status = "skipped: " + entry.Reason
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.Kind, entry.Name, status)
+	}
+
+	return tw.Flush()
+}
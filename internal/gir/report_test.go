@@ -0,0 +1,55 @@
+package gir
+
+import "testing"
+
+func TestReportManualAndFailed(t *testing.T) {
+	namespace := testNamespace()
+	namespace.Classes = append(namespace.Classes, Class{
+		Name:  "Broken",
+		CType: "GspellBroken",
+		Methods: []Method{{
+			Name:        "frob",
+			CIdentifier: "gspell_broken_frob",
+		}},
+	})
+	withNamespace(t, namespace)
+
+	oldManual, oldDiagnostics := manualDecls, diagnostics
+	t.Cleanup(func() { manualDecls, diagnostics = oldManual, oldDiagnostics })
+
+	manualDecls = map[string]bool{"LanguageGetDefault": true}
+	diagnostics = []Diagnostic{{
+		Severity: SeverityError,
+		Path:     "Gspell.Broken",
+		Message:  "unknown native type",
+	}}
+
+	r := activeNamespace.Report()
+
+	want := map[string]string{
+		"Checker":              "generated",
+		"Broken":               "failed: unknown native type",
+		"Broken.frob":          "failed: unknown native type",
+		"language_get_default": "manual",
+	}
+
+	for _, entry := range r.Entries {
+		var got string
+		switch {
+		case entry.Manual:
+			got = "manual"
+		case entry.Generated:
+			got = "generated"
+		default:
+			got = entry.Reason
+		}
+
+		if got != want[entry.Name] {
+			t.Errorf("%s: got %q, want %q", entry.Name, got, want[entry.Name])
+		}
+	}
+
+	if r.Total != 4 || r.Generated != 1 || r.Manual != 1 {
+		t.Errorf("got total %d, generated %d, manual %d", r.Total, r.Generated, r.Manual)
+	}
+}
@@ -2,14 +2,29 @@ package gir
 
 import (
 	"encoding/xml"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 )
 
+// XML namespaces of the gir format.
+const (
+	coreNamespace = "http://www.gtk.org/introspection/core/1.0"
+	cNamespace    = "http://www.gtk.org/introspection/c/1.0"
+)
+
+// RepositoryHeader is the part of a gir file that comes before its namespace.
+type RepositoryHeader struct {
+	Includes  []Include  `xml:"http://www.gtk.org/introspection/core/1.0 include"`
+	Packages  []Package  `xml:"http://www.gtk.org/introspection/core/1.0 package"`
+	CIncludes []CInclude `xml:"http://www.gtk.org/introspection/c/1.0 include"`
+}
+
 var repository struct {
-	Includes   []Include   `xml:"http://www.gtk.org/introspection/core/1.0 include"`
-	CIncludes  []CInclude  `xml:"http://www.gtk.org/introspection/c/1.0 include"`
+	RepositoryHeader
 	Namespaces []Namespace `xml:"http://www.gtk.org/introspection/core/1.0 namespace"`
 }
 
@@ -20,19 +35,105 @@ func ParseRepositoryFile(path string) error {
 	}
 	defer f.Close()
 
-	if err := xml.NewDecoder(f).Decode(&repository); err != nil {
+	if err := ParseRepository(f); err != nil {
+		return err
+	}
+
+	repositoryDirs = []string{filepath.Dir(path)}
+	return nil
+}
+
+// ParseRepository parses the gir XML from the given reader. The previously
+// parsed repository is replaced.
+func ParseRepository(r io.Reader) error {
+	repository.RepositoryHeader = RepositoryHeader{}
+	repository.Namespaces = nil
+	repositoryDirs = nil
+	cgoDirectives = nil
+
+	if err := xml.NewDecoder(r).Decode(&repository); err != nil {
 		return errors.Wrap(err, "Failed to decode gir XML")
 	}
 
 	return nil
 }
 
+// ParseRepositoryHeader parses only the header of the gir XML from the given
+// reader, which is much faster than parsing the whole repository.
+func ParseRepositoryHeader(r io.Reader) (RepositoryHeader, error) {
+	var header RepositoryHeader
+	var d = xml.NewDecoder(r)
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return header, nil
+			}
+			return header, errors.Wrap(err, "Failed to decode gir XML")
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name {
+		case xml.Name{Space: coreNamespace, Local: "include"}:
+			var include Include
+			err = d.DecodeElement(&include, &start)
+			header.Includes = append(header.Includes, include)
+		case xml.Name{Space: coreNamespace, Local: "package"}:
+			var pkg Package
+			err = d.DecodeElement(&pkg, &start)
+			header.Packages = append(header.Packages, pkg)
+		case xml.Name{Space: cNamespace, Local: "include"}:
+			var include CInclude
+			err = d.DecodeElement(&include, &start)
+			header.CIncludes = append(header.CIncludes, include)
+		case xml.Name{Space: coreNamespace, Local: "namespace"}:
+			return header, nil
+		}
+
+		if err != nil {
+			return header, errors.Wrap(err, "Failed to decode gir XML")
+		}
+	}
+}
+
+// Header returns the header of the parsed repository.
+func Header() RepositoryHeader {
+	return repository.RepositoryHeader
+}
+
 func Namespaces() []Namespace {
 	return repository.Namespaces
 }
 
+// FindNamespace returns the index of the namespace with the given name and
+// version. Empty strings match anything, so the first namespace is returned if
+// both are empty.
+func FindNamespace(name, version string) (int, error) {
+	for i, namespace := range repository.Namespaces {
+		if name != "" && namespace.Name != name {
+			continue
+		}
+		if version != "" && namespace.Version != version {
+			continue
+		}
+		return i, nil
+	}
+
+	return 0, fmt.Errorf("no namespace matching name %q and version %q", name, version)
+}
+
 var activeNamespace namespaceGenerator
 
+// ActiveNamespace returns the namespace set by SetActiveNamespace.
+func ActiveNamespace() namespaceGenerator {
+	return activeNamespace
+}
+
 // SetActiveNamespace sets the active global namespace to generate from. This
 // method is not thread safe. As such, only ONE namespace can be generated at a
 // time.
@@ -3,7 +3,6 @@ package gir
 import (
 	"encoding/xml"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
@@ -146,6 +145,10 @@ func (t Type) Map() *jen.Statement {
 		return nil
 	}
 
+	if ext, name, ok := externalType(t.Name); ok {
+		return t.mapExternal(ext, name)
+	}
+
 	if parts := strings.Split(t.Name, "."); len(parts) == 2 {
 		var stmt = new(jen.Statement)
 		if typeMapInterface(parts) && t.IsPtr() {
@@ -236,7 +239,7 @@ func (t Type) GenCaster(tmpVar, value *jen.Statement) *jen.Statement {
 
 	switch goType {
 	case "bool":
-		stmt.Id("gobool")
+		return stmt.Qual(RuntimePath, "Gobool").Call(jen.Int().Call(value))
 	case "string":
 		stmt.Qual("C", "GoString")
 	case "uintptr":
@@ -244,7 +247,9 @@ func (t Type) GenCaster(tmpVar, value *jen.Statement) *jen.Statement {
 
 	// Handle IWidget separately.
 	case "gtk.IWidget":
-		stmt = jen.List(tmpVar, jen.Err()).Op(":=").Id("castWidget").Call(value)
+		stmt = jen.List(tmpVar, jen.Err()).Op(":=").Qual(RuntimePath, "CastWidget").Call(
+			jen.Qual("unsafe", "Pointer").Call(value),
+		)
 		stmt.Line()
 		stmt.If(jen.Err().Op("!=").Nil()).Block(
 			jen.Panic(
@@ -263,22 +268,22 @@ func (t Type) GenCaster(tmpVar, value *jen.Statement) *jen.Statement {
 
 	// Handle *glib.SList separately.
 	case "glib.SList", "*glib.SList":
-		return stmt.Qual("github.com/gotk3/gotk3/glib", "WrapSList").Call(
-			jen.Uintptr().Call(jen.Qual("unsafe", "Pointer").Call(value)),
+		return stmt.Qual(RuntimePath, "WrapSList").Call(
+			jen.Qual("unsafe", "Pointer").Call(value), jen.Nil(),
 		)
 
 	// Handle *glib.List separately.
 	case "glib.List", "*glib.List":
-		stmt.Qual("github.com/gotk3/gotk3/glib", "WrapList").Call(
-			jen.Uintptr().Call(jen.Qual("unsafe", "Pointer").Call(value)),
-		)
-
+		var wrapper = jen.Nil()
 		if t.ChildType != nil {
-			stmt.Line()
-			stmt.Add(t.ChildType.GenListWrapper(tmpVar))
+			if w := t.ChildType.GenListWrapper(); w != nil {
+				wrapper = w
+			}
 		}
 
-		return stmt
+		return stmt.Qual(RuntimePath, "WrapList").Call(
+			jen.Qual("unsafe", "Pointer").Call(value), wrapper,
+		)
 
 	// Handle glib.ListModel separately. TODO: handle all glib types that
 	// embed *glib.Object.
@@ -292,9 +297,15 @@ func (t Type) GenCaster(tmpVar, value *jen.Statement) *jen.Statement {
 		)
 
 	default:
+		if ext, name, ok := externalType(t.Name); ok {
+			if caster := t.genExternalCaster(ext, name, tmpVar, value); caster != nil {
+				return caster
+			}
+		}
+
 		switch {
 		case t.IsFunc():
-			log.Panicln("Unsure GenCaster for func type", t.Name)
+			fail("unsure how to cast func type %s", t.Name)
 		case t.IsEnum():
 			break
 		case t.IsInterface():
@@ -337,7 +348,9 @@ func (t Type) GenCaster(tmpVar, value *jen.Statement) *jen.Statement {
 	return stmt
 }
 
-func (t Type) GenListWrapper(listVar *jen.Statement) *jen.Statement {
+// GenListWrapper generates the function that converts the data of a list with
+// elements of type t. Nil is returned if the elements aren't converted.
+func (t Type) GenListWrapper() *jen.Statement {
 	var ptr = jen.Id("ptr")
 	var tmp = jen.Id("val")
 
@@ -348,18 +361,16 @@ func (t Type) GenListWrapper(listVar *jen.Statement) *jen.Statement {
 			t.CType = nsp.CType + "*"
 
 		case Class:
-			log.Panicln("Unknown class of type", t.Name)
+			fail("unknown list element class %s", t.Name)
 
 		case nil:
 			return nil
 		}
 	}
 
-	return jen.Add(listVar).Dot("DataWrapper").Call(
-		jen.Func().Params(jen.Add(ptr).Qual("unsafe", "Pointer")).Interface().Block(
-			jen.Add(t.GenCaster(tmp, ptr)),
-			jen.Return().Add(tmp),
-		),
+	return jen.Func().Params(jen.Add(ptr).Qual("unsafe", "Pointer")).Interface().Block(
+		jen.Add(t.GenCaster(tmp, ptr)),
+		jen.Return().Add(tmp),
 	)
 }
 
@@ -416,7 +427,7 @@ var knownEnums = []string{
 }
 
 func (t Type) IsEnum() bool {
-	for _, enum := range activeNamespace.Enums {
+	for _, enum := range activeNamespace.AllEnums() {
 		if enum.Name == t.Name {
 			return true
 		}
@@ -428,6 +439,10 @@ func (t Type) IsEnum() bool {
 		}
 	}
 
+	if ext, name, ok := externalType(t.Name); ok {
+		return ext.enums[name]
+	}
+
 	return false
 }
 
@@ -437,7 +452,7 @@ func (t Type) GenCCaster(value *jen.Statement) *jen.Statement {
 
 	switch goType := t.GoType(); goType {
 	case "bool":
-		return jen.Id("cbool").Call(value)
+		return jen.Qual("C", "gboolean").Call(jen.Qual(RuntimePath, "Cbool").Call(value))
 	case "float32":
 		return jen.Qual("C", "gfloat").Call(value)
 	case "float64":
@@ -447,7 +462,9 @@ func (t Type) GenCCaster(value *jen.Statement) *jen.Statement {
 	case "string":
 		return jen.Qual("C", "CString").Call(value)
 	case "gtk.IWidget":
-		return jen.Id("cwidget").Call(value)
+		return jen.Parens(jen.Op("*").Qual("C", "GtkWidget")).Call(
+			jen.Qual(RuntimePath, "WidgetPtr").Call(value),
+		)
 	case "glib.Type":
 		return jen.Qual("C", "GType").Call(value)
 	case "*gdk.Rectangle":
@@ -460,7 +477,7 @@ func (t Type) GenCCaster(value *jen.Statement) *jen.Statement {
 		case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "uint"):
 			return jen.Qual("C", t.CType).Call(value)
 		case t.IsFunc():
-			return ZeroByteCast(jen.Qual("C", CallbackExternCName(t.Name)))
+			return ZeroByteCast(jen.Qual("C", CallbackTrampolineCName(t.Name)))
 		case t.IsEnum():
 			return t.GenCGoType().Call(value)
 		}
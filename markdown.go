@@ -0,0 +1,411 @@
+package gspell
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// mdState is the block state of a Markdown document at the start of a line.
+type mdState struct {
+	// fence is the character of the fenced code block the line is in, if any,
+	// and fenceQuotes the depth of the block quotes holding it.
+	fence       byte
+	fenceLen    int
+	fenceQuotes int
+	// listIndent is the column of the content of the current list item, under
+	// which lines continue the item, or 0 outside of lists.
+	listIndent int
+	// paragraph is true if the previous line is part of a paragraph, in which
+	// case an indented line continues it instead of starting a code block.
+	paragraph bool
+	// htmlComment is true inside an HTML comment spanning lines.
+	htmlComment bool
+	// unknown marks states that still have to be computed.
+	unknown bool
+}
+
+var (
+	mdRefDefRegex = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:[ \t]*(\S+)`)
+	mdHTMLRegex   = regexp.MustCompile(`^(?:` +
+		`<[A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*>` + // autolink
+		`|<[^\s<>@]+@[^\s<>]+>` + // email autolink
+		`|</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>` + // tag
+		`|<[?!][^<>]*>)`, // declaration or processing instruction
+	)
+)
+
+// IgnoreMarkdown ignores the parts of a Markdown document that aren't prose:
+// code blocks, inline code, link destinations and labels, autolinks, and HTML
+// tags and comments. Code spans can't span lines.
+var IgnoreMarkdown IgnoreRule = func(text string) []Range {
+	var ranges []Range
+	var state mdState
+
+	for offset := 0; offset < len(text); {
+		end := strings.IndexByte(text[offset:], '\n')
+		if end == -1 {
+			end = len(text)
+		} else {
+			end += offset
+		}
+
+		var lineRanges []Range
+		lineRanges, state = scanMarkdownLine(strings.TrimSuffix(text[offset:end], "\r"), state)
+
+		for _, r := range lineRanges {
+			ranges = append(ranges, Range{offset + r.Start, offset + r.End})
+		}
+
+		offset = end + 1
+	}
+
+	return ranges
+}
+
+// scanMarkdownLine returns the ignored ranges of a line that starts in the
+// given state, and the state of the next line.
+func scanMarkdownLine(line string, state mdState) ([]Range, mdState) {
+	quotes, offset := mdQuotes(line)
+	if state.fence != 0 && quotes < state.fenceQuotes {
+		// The block quote holding the code block ended.
+		state.fence = 0
+		state.fenceLen = 0
+		state.fenceQuotes = 0
+	}
+
+	ranges, state := scanMarkdownContent(line[offset:], quotes, state)
+	for i := range ranges {
+		ranges[i].Start += offset
+		ranges[i].End += offset
+	}
+
+	return ranges, state
+}
+
+// scanMarkdownContent scans a line without its block quote markers.
+func scanMarkdownContent(line string, quotes int, state mdState) ([]Range, mdState) {
+	var whole = []Range{{0, len(line)}}
+	var column, rest = mdIndent(line)
+
+	// Lines indented under a list item continue it, so their indentation is
+	// counted from its content.
+	var indent = column
+	if state.listIndent > 0 && rest != "" {
+		switch {
+		case column >= state.listIndent:
+			indent -= state.listIndent
+		case !state.paragraph && state.fence == 0 && mdListItem(rest) == 0:
+			// A line that isn't indented after a blank line ends the list.
+			state.listIndent = 0
+		}
+	}
+
+	if state.fence != 0 {
+		if indent < 4 && mdFenceCloses(rest, state) {
+			state.fence = 0
+			state.fenceLen = 0
+			state.fenceQuotes = 0
+		}
+		return whole, state
+	}
+
+	var ranges []Range
+	var start int
+
+	if state.htmlComment {
+		end := strings.Index(line, "-->")
+		if end == -1 {
+			return whole, state
+		}
+
+		start = end + len("-->")
+		ranges = append(ranges, Range{0, start})
+		state.htmlComment = false
+	} else {
+		switch {
+		case rest == "":
+			state.paragraph = false
+			return nil, state
+
+		case indent >= 4 && !state.paragraph:
+			return whole, state
+
+		case indent < 4:
+			if fence, n := mdFenceOpens(rest); n > 0 {
+				state.fence = fence
+				state.fenceLen = n
+				state.fenceQuotes = quotes
+				state.paragraph = false
+				return whole, state
+			}
+
+			if m := mdRefDefRegex.FindStringSubmatchIndex(rest); m != nil {
+				state.paragraph = false
+				return []Range{{len(line) - len(rest) + m[2], len(line) - len(rest) + m[3]}}, state
+			}
+
+			if n := mdListItem(rest); n > 0 {
+				state.listIndent = column + n
+			}
+		}
+	}
+
+	ranges, state = scanMarkdownInline(line, start, ranges, state)
+	state.paragraph = !mdIsHeading(rest)
+
+	return ranges, state
+}
+
+// scanMarkdownInline appends the ignored inline ranges of line from start.
+func scanMarkdownInline(line string, start int, ranges []Range, state mdState) ([]Range, mdState) {
+	for i := start; i < len(line); {
+		switch line[i] {
+		case '\\':
+			i += 2
+
+		case '`':
+			n := mdRunLength(line, i, '`')
+			if end := mdFindRun(line, i+n, '`', n); end != -1 {
+				ranges = append(ranges, Range{i, end + n})
+				i = end + n
+			} else {
+				i += n
+			}
+
+		case '<':
+			if strings.HasPrefix(line[i:], "<!--") {
+				end := strings.Index(line[i+4:], "-->")
+				if end == -1 {
+					state.htmlComment = true
+					return append(ranges, Range{i, len(line)}), state
+				}
+
+				end += i + 4 + len("-->")
+				ranges = append(ranges, Range{i, end})
+				i = end
+				continue
+			}
+
+			if loc := mdHTMLRegex.FindStringIndex(line[i:]); loc != nil {
+				ranges = append(ranges, Range{i, i + loc[1]})
+				i += loc[1]
+				continue
+			}
+
+			i++
+
+		case ']':
+			// Link destination, as in [text](url "title"). The title is prose.
+			if i+1 < len(line) && line[i+1] == '(' {
+				if end := mdMatchParen(line, i+1); end != -1 {
+					ranges = append(ranges, mdLinkDestination(line, i+2, end))
+					i = end + 1
+					continue
+				}
+			}
+
+			// Reference label, as in [text][label].
+			if i+1 < len(line) && line[i+1] == '[' {
+				if end := strings.IndexByte(line[i+2:], ']'); end != -1 {
+					end += i + 2 + 1
+					ranges = append(ranges, Range{i + 1, end})
+					i = end
+					continue
+				}
+			}
+
+			i++
+
+		default:
+			i++
+		}
+	}
+
+	return ranges, state
+}
+
+// mdQuotes returns the depth of the block quote markers starting line and the
+// length of the markers, including the space following each of them.
+func mdQuotes(line string) (int, int) {
+	var quotes, offset int
+	for {
+		i := offset + mdRunLength(line, offset, ' ')
+		if i-offset > 3 || i == len(line) || line[i] != '>' {
+			return quotes, offset
+		}
+
+		quotes++
+		offset = i + 1
+		if offset < len(line) && line[offset] == ' ' {
+			offset++
+		}
+	}
+}
+
+// mdListItem returns the width of the list item marker starting rest, with the
+// spaces after it, or 0 if rest doesn't start a list item. The width is the
+// indentation of the lines continuing the item.
+func mdListItem(rest string) int {
+	var n int
+	switch {
+	case rest == "":
+		return 0
+
+	case rest[0] == '-' || rest[0] == '+' || rest[0] == '*':
+		n = 1
+
+	default:
+		for n < len(rest) && n < 9 && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == 0 || n == len(rest) || (rest[n] != '.' && rest[n] != ')') {
+			return 0
+		}
+		n++
+	}
+
+	if n == len(rest) {
+		return n + 1
+	}
+	if rest[n] != ' ' {
+		return 0
+	}
+
+	// Beyond 4 spaces, the content is an indented code block starting after
+	// the first space.
+	spaces := mdRunLength(rest, n, ' ')
+	if spaces > 4 || n+spaces == len(rest) {
+		spaces = 1
+	}
+
+	return n + spaces
+}
+
+// mdLinkDestination returns the range of the link destination between start
+// and the closing parenthesis at end, leaving out the title.
+func mdLinkDestination(line string, start, end int) Range {
+	start += mdRunLength(line[:end], start, ' ')
+
+	if start < end && line[start] == '<' {
+		if i := strings.IndexByte(line[start:end], '>'); i != -1 {
+			return Range{start, start + i + 1}
+		}
+	}
+
+	i := start
+	for i < end && line[i] != ' ' && line[i] != '\t' && line[i] != '\n' {
+		i++
+	}
+
+	return Range{start, i}
+}
+
+// mdIndent returns the indentation of line in columns, with tabs stopping at
+// multiples of 4, and the rest of the line.
+func mdIndent(line string) (int, string) {
+	var columns int
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			columns++
+		case '\t':
+			columns += 4 - columns%4
+		default:
+			return columns, line[i:]
+		}
+	}
+	return columns, ""
+}
+
+// mdFenceOpens returns the character and length of the code fence opened by
+// the unindented line, or 0 if it doesn't open one.
+func mdFenceOpens(rest string) (byte, int) {
+	if rest == "" || (rest[0] != '`' && rest[0] != '~') {
+		return 0, 0
+	}
+
+	fence := rest[0]
+	n := mdRunLength(rest, 0, fence)
+	if n < 3 {
+		return 0, 0
+	}
+
+	// The info string of a backtick fence can't have backticks.
+	if fence == '`' && strings.IndexByte(rest[n:], '`') != -1 {
+		return 0, 0
+	}
+
+	return fence, n
+}
+
+func mdFenceCloses(rest string, state mdState) bool {
+	n := mdRunLength(rest, 0, state.fence)
+	return n >= state.fenceLen && strings.TrimSpace(rest[n:]) == ""
+}
+
+func mdIsHeading(rest string) bool {
+	n := mdRunLength(rest, 0, '#')
+	return n >= 1 && n <= 6 && (n == len(rest) || rest[n] == ' ' || rest[n] == '\t')
+}
+
+func mdRunLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// mdFindRun returns the index of the next run of exactly n c characters from
+// i, or -1.
+func mdFindRun(s string, i int, c byte, n int) int {
+	for i < len(s) {
+		if s[i] != c {
+			i++
+			continue
+		}
+
+		run := mdRunLength(s, i, c)
+		if run == n {
+			return i
+		}
+		i += run
+	}
+
+	return -1
+}
+
+// mdMatchParen returns the index of the parenthesis closing the one at open,
+// or -1.
+func mdMatchParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// AttachMarkdown applies Markdown-aware checking to the inline checking of
+// buffer: the parts of the document that aren't prose are tagged with
+// NoSpellCheckTag, along with the ranges ignored by the filter's rules, which
+// are applied line by line. The tags are updated incrementally as the buffer
+// changes, until the returned function is called. Like Attach, it only removes
+// the tags it applied, and a buffer shouldn't have several filters attached.
+//
+// Headless checks can use IgnoreMarkdown for the same results.
+func (f *Filter) AttachMarkdown(buffer *gtk.TextBuffer) (detach func()) {
+	return f.attach(buffer, true).detach
+}
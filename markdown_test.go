@@ -0,0 +1,177 @@
+package gspell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{{
+		name: "prose",
+		text: "Just some *prose*.\n# A heading",
+	}, {
+		name: "fenced code",
+		text: "text\n```go\ncode here\n```\nprose",
+		want: []string{"```go", "code here", "```"},
+	}, {
+		name: "fenced code in block quote",
+		text: "> ```\n> code\n> ```\n> prose",
+		want: []string{"```", "code", "```"},
+	}, {
+		name: "fenced code ended by its block quote",
+		text: "> ```\n> code\nprose",
+		want: []string{"```", "code"},
+	}, {
+		name: "indented code in block quote",
+		text: "> para\n>\n>     code",
+		want: []string{"    code"},
+	}, {
+		name: "indented code",
+		text: "para\n\n    code",
+		want: []string{"    code"},
+	}, {
+		name: "paragraph continuation",
+		text: "para\n    more",
+	}, {
+		name: "list item continuation",
+		text: "- item\n\n    more text\n\n      code",
+		want: []string{"      code"},
+	}, {
+		name: "nested list item continuation",
+		text: "- a\n  - b\n\n      text",
+	}, {
+		name: "ordered list item continuation",
+		text: "1. item\n\n   more\n\n       code",
+		want: []string{"       code"},
+	}, {
+		name: "list ended",
+		text: "- item\n\nPara\n\n    code",
+		want: []string{"    code"},
+	}, {
+		name: "fenced code in list item",
+		text: "- item\n\n  ```\n  code\n  ```\n  more",
+		want: []string{"  ```", "  code", "  ```"},
+	}, {
+		name: "link destination",
+		text: `see [the docs](https://x.org/a "The title") now`,
+		want: []string{"https://x.org/a"},
+	}, {
+		name: "angle link destination",
+		text: `[a](<my file.md> 'title')`,
+		want: []string{"<my file.md>"},
+	}, {
+		name: "reference label",
+		text: "[text][label]",
+		want: []string{"[label]"},
+	}, {
+		name: "reference definition",
+		text: `[label]: https://x.org "Title"`,
+		want: []string{"https://x.org"},
+	}, {
+		name: "reference definition in block quote",
+		text: "> [label]: https://x.org",
+		want: []string{"https://x.org"},
+	}, {
+		name: "inline code and HTML",
+		text: "use `go test` and <br/> <!-- note --> <https://x.org>",
+		want: []string{"`go test`", "<br/>", "<!-- note -->", "<https://x.org>"},
+	}, {
+		name: "HTML comment spanning lines",
+		text: "a <!-- b\nc --> d",
+		want: []string{"<!-- b", "c -->"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, r := range IgnoreMarkdown(test.text) {
+				got = append(got, test.text[r.Start:r.End])
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// markdownStates scans all lines and returns the state at the start of each.
+func markdownStates(lines []string) []mdState {
+	states := make([]mdState, len(lines))
+	scanStates(states, 0, len(states), func(line int, state mdState) mdState {
+		_, next := scanMarkdownLine(lines[line], state)
+		return next
+	})
+	return states
+}
+
+// TestScanStatesIncremental checks that re-scanning after an insertion or a
+// deletion, like the buffers attached with AttachMarkdown do, gives the states
+// of a full scan.
+func TestScanStatesIncremental(t *testing.T) {
+	doc := strings.Join([]string{
+		"Intro",
+		"",
+		"```",
+		"code",
+		"```",
+		"",
+		"- item",
+		"",
+		"      code",
+		"> quote",
+		"end",
+	}, "\n")
+
+	// offset returns the offset of the column of a line in doc.
+	offset := func(line, column int) int {
+		lines := strings.SplitAfter(doc, "\n")
+		return len(strings.Join(lines[:line], "")) + column
+	}
+
+	tests := []struct {
+		name   string
+		at     int
+		delete int
+		insert string
+	}{
+		{name: "insert in a line", at: offset(0, 2), insert: "ro"},
+		{name: "open a fence", at: offset(1, 0), insert: "```"},
+		{name: "insert a fence", at: offset(0, 5), insert: "\n```\nnew code\n```"},
+		{name: "insert lines", at: offset(6, 6), insert: "\n\nPara\n\n    code"},
+		{name: "delete a fence", at: offset(2, 0), delete: 4},
+		{name: "delete lines", at: offset(1, 0), delete: offset(5, 0) - offset(1, 0)},
+		{name: "join lines", at: offset(9, 7), delete: 1},
+		{name: "quote a fence", at: offset(2, 0), insert: "> "},
+		{name: "start a comment", at: offset(0, 0), insert: "<!--"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			states := markdownStates(strings.Split(doc, "\n"))
+
+			from := strings.Count(doc[:test.at], "\n")
+			edited := doc[:test.at] + test.insert + doc[test.at+test.delete:]
+			lines := strings.Split(edited, "\n")
+
+			var to int
+			states, to = shiftStates(states, from, len(lines))
+			scanStates(states, from, to, func(line int, state mdState) mdState {
+				if state.unknown {
+					t.Errorf("line %d was scanned in an unknown state", line)
+				}
+				_, next := scanMarkdownLine(lines[line], state)
+				return next
+			})
+
+			if want := markdownStates(lines); !reflect.DeepEqual(states, want) {
+				t.Errorf("got  %+v\nwant %+v", states, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package gspell
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// MultiChecker checks the spelling in several languages at once: a word is
+// correctly spelled if any of its Checkers accepts it. The first Checker is the
+// primary one, which splits texts into words and does the inline checking.
+//
+// Checkers without a language are skipped, since they accept every word. The
+// primary Checker still needs one to find misspellings in texts.
+type MultiChecker struct {
+	checkers []*Checker
+}
+
+// NewMultiChecker creates a MultiChecker from the primary Checker followed by
+// the others.
+func NewMultiChecker(primary *Checker, others ...*Checker) *MultiChecker {
+	return &MultiChecker{
+		checkers: append([]*Checker{primary}, others...),
+	}
+}
+
+// Checkers returns the Checkers in order, starting with the primary one.
+func (m *MultiChecker) Checkers() []*Checker {
+	return append([]*Checker(nil), m.checkers...)
+}
+
+// CheckWord returns the language of the first Checker that accepts the word,
+// or nil if the word is misspelled in all languages.
+func (m *MultiChecker) CheckWord(word string) (*Language, error) {
+	langs, err := m.CheckWords([]string{word})
+	if err != nil {
+		return nil, err
+	}
+	return langs[0], nil
+}
+
+// CheckWords checks all words like CheckWord, with a single cgo call per
+// language. Each Checker only checks the words rejected by the previous ones.
+func (m *MultiChecker) CheckWords(words []string) ([]*Language, error) {
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	langs := make([]*Language, len(words))
+	if err := accept(m.checkers, words, langs); err != nil {
+		return nil, err
+	}
+
+	return langs, nil
+}
+
+// accept sets langs[i] to the language of the first checker accepting
+// words[i], skipping the words that already have a language.
+func accept(checkers []*Checker, words []string, langs []*Language) error {
+	for _, checker := range checkers {
+		lang := checker.GetLanguage()
+		if lang == nil {
+			continue
+		}
+
+		var pending []string
+		var indices []int
+
+		for i, word := range words {
+			if langs[i] == nil {
+				pending = append(pending, word)
+				indices = append(indices, i)
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		results, err := checker.CheckWords(pending)
+		if err != nil {
+			return err
+		}
+
+		for j, ok := range results {
+			if ok {
+				langs[indices[j]] = lang
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetSuggestions merges the suggestions of all languages for a word. They're
+// ranked by their best position among the suggestions of each language, then
+// by how close they are to the word, then by the order of the Checkers.
+func (m *MultiChecker) GetSuggestions(word string) []string {
+	lists := make([][]string, len(m.checkers))
+	for i, checker := range m.checkers {
+		if checker.GetLanguage() != nil {
+			lists[i] = checker.suggestions(word)
+		}
+	}
+
+	return mergeSuggestions(word, lists)
+}
+
+// mergeSuggestions merges the lists of suggestions of each Checker for word
+// like GetSuggestions.
+func mergeSuggestions(word string, lists [][]string) []string {
+	type suggestion struct {
+		word     string
+		rank     int
+		distance int
+		checker  int
+	}
+
+	var merged []suggestion
+	var indices = map[string]int{}
+
+	for i, list := range lists {
+		for rank, s := range list {
+			if j, ok := indices[s]; ok {
+				if rank < merged[j].rank {
+					merged[j].rank = rank
+				}
+				continue
+			}
+
+			indices[s] = len(merged)
+			merged = append(merged, suggestion{
+				word:     s,
+				rank:     rank,
+				distance: editDistance(word, s),
+				checker:  i,
+			})
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.checker < b.checker
+	})
+
+	suggestions := make([]string, len(merged))
+	for i, s := range merged {
+		suggestions[i] = s.word
+	}
+
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between the lowercased runes
+// of a and b.
+func editDistance(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			next := min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev = row[j]
+			row[j] = next
+		}
+	}
+
+	return row[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// CheckText checks the spelling of a plain text like Checker.CheckText, but
+// only returns the words misspelled in all languages. The suggestions of the
+// misspellings are merged like GetSuggestions.
+func (m *MultiChecker) CheckText(text string) ([]Misspelling, error) {
+	misspellings, err := m.checkers[0].CheckText(text)
+	if err != nil || len(misspellings) == 0 {
+		return nil, err
+	}
+
+	langs, err := m.acceptOthers(misspellings)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := misspellings[:0]
+	for i, misspelling := range misspellings {
+		if langs[i] == nil {
+			misspelling.suggestions.suggest = m.GetSuggestions
+			kept = append(kept, misspelling)
+		}
+	}
+
+	return kept, nil
+}
+
+// acceptOthers returns the languages of the checkers other than the primary
+// one that accept each misspelling.
+func (m *MultiChecker) acceptOthers(misspellings []Misspelling) ([]*Language, error) {
+	words := make([]string, len(misspellings))
+	for i, misspelling := range misspellings {
+		words[i] = misspelling.Word
+	}
+
+	langs := make([]*Language, len(words))
+	if err := accept(m.checkers[1:], words, langs); err != nil {
+		return nil, err
+	}
+
+	return langs, nil
+}
+
+// IgnoreRule returns a rule ignoring the words that the primary Checker rejects
+// but another Checker accepts. The inline checker of the primary Checker then
+// skips them, so a filter with this rule makes a TextView check several
+// languages. Attached filters apply it to the changed lines only, but it has to
+// check the words again when the words accepted by the Checkers change, which
+// Attach takes care of.
+func (m *MultiChecker) IgnoreRule() IgnoreRule {
+	return func(text string) []Range {
+		if len(m.checkers) < 2 {
+			return nil
+		}
+
+		misspellings, err := m.checkers[0].CheckText(text)
+		if err != nil || len(misspellings) == 0 {
+			return nil
+		}
+
+		langs, err := m.acceptOthers(misspellings)
+		if err != nil {
+			return nil
+		}
+
+		var ranges []Range
+		for i, misspelling := range misspellings {
+			if langs[i] != nil {
+				ranges = append(ranges, Range{misspelling.Start, misspelling.End})
+			}
+		}
+
+		return ranges
+	}
+}
+
+// Attach makes the primary Checker check the given buffer inline, and applies a
+// filter with IgnoreRule to it until the returned function is called. The
+// changed lines are filtered again as the buffer changes. When a Checker gets
+// new words, the lines containing them are filtered again once the main loop is
+// idle, and the whole buffer when its language changes or its session is
+// cleared.
+//
+// A buffer shouldn't have other filters attached, since they would remove each
+// other's tags. To ignore more, such as URLs, use AttachFilter, and to check
+// Markdown, use AttachMarkdown instead of Filter.AttachMarkdown.
+func (m *MultiChecker) Attach(buffer *gtk.TextBuffer) (detach func()) {
+	return m.AttachFilter(buffer, nil)
+}
+
+// AttachFilter is like Attach, but also applies the rules of the given filter,
+// which can be nil.
+func (m *MultiChecker) AttachFilter(buffer *gtk.TextBuffer, filter *Filter) (detach func()) {
+	return m.attach(buffer, filter, false)
+}
+
+// AttachMarkdown is like AttachFilter, but checks the buffer as Markdown like
+// Filter.AttachMarkdown. The filter can be nil.
+func (m *MultiChecker) AttachMarkdown(buffer *gtk.TextBuffer, filter *Filter) (detach func()) {
+	return m.attach(buffer, filter, true)
+}
+
+func (m *MultiChecker) attach(buffer *gtk.TextBuffer, filter *Filter, markdown bool) func() {
+	GetFromGtkTextBuffer(buffer).SetSpellChecker(m.checkers[0])
+
+	rules := []IgnoreRule{m.IgnoreRule()}
+	if filter != nil {
+		rules = append(rules, filter.rules...)
+	}
+
+	tagged := NewFilter(rules...).attach(buffer, markdown)
+
+	// Added words are signalled once per batch with only the last word, so the
+	// whole buffer is re-tagged, once the main loop is idle to cover the
+	// batches of several checkers.
+	var idle glib.SourceHandle
+
+	wordAdded := func() {
+		if idle == 0 {
+			idle = glib.IdleAdd(func() {
+				idle = 0
+				tagged.retag()
+			})
+		}
+	}
+
+	handles := make([][]glib.SignalHandle, len(m.checkers))
+	for i, checker := range m.checkers {
+		handles[i] = []glib.SignalHandle{
+			checker.Connect("notify::language", tagged.retag),
+			checker.Connect("session-cleared", tagged.retag),
+			checker.Connect("word-added-to-personal", wordAdded),
+			checker.Connect("word-added-to-session", wordAdded),
+		}
+	}
+
+	return func() {
+		for i, checker := range m.checkers {
+			for _, handle := range handles[i] {
+				checker.HandlerDisconnect(handle)
+			}
+		}
+
+		if idle != 0 {
+			glib.SourceRemove(idle)
+		}
+
+		tagged.detach()
+	}
+}
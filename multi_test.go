@@ -0,0 +1,79 @@
+package gspell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"word", "word", 0},
+		{"Word", "wORD", 0},
+		{"wrold", "world", 2},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"naïve", "naive", 1},
+		{"Größe", "grosse", 3},
+	}
+
+	for _, test := range tests {
+		if got := editDistance(test.a, test.b); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := editDistance(test.b, test.a); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", test.b, test.a, got, test.want)
+		}
+	}
+}
+
+func TestMergeSuggestions(t *testing.T) {
+	tests := []struct {
+		name  string
+		word  string
+		lists [][]string
+		want  []string
+	}{{
+		name: "none",
+		word: "wrold",
+		want: []string{},
+	}, {
+		name:  "skipped checker",
+		word:  "wrold",
+		lists: [][]string{nil, {"world", "wold"}},
+		want:  []string{"world", "wold"},
+	}, {
+		name:  "rank first",
+		word:  "colr",
+		lists: [][]string{{"color", "cold"}, {"colour"}},
+		want:  []string{"color", "colour", "cold"},
+	}, {
+		name:  "distance within a rank",
+		word:  "colr",
+		lists: [][]string{{"colour"}, {"color"}},
+		want:  []string{"color", "colour"},
+	}, {
+		name:  "checker order within a distance",
+		word:  "hte",
+		lists: [][]string{{"the"}, {"He"}, {"hate"}},
+		want:  []string{"He", "hate", "the"},
+	}, {
+		name:  "duplicates keep their best rank",
+		word:  "teh",
+		lists: [][]string{{"tech", "ten", "the"}, {"the"}},
+		want:  []string{"tech", "the", "ten"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mergeSuggestions(test.word, test.lists); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package gspell
+
+// #include <stdlib.h>
+// #include "personal.h"
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// WordListFormat is the format of an imported or exported word list.
+type WordListFormat int
+
+const (
+	// PlainText is one word per line. Empty lines and lines starting with #
+	// are ignored.
+	PlainText WordListFormat = iota
+	// HunspellDic is the format of Hunspell .dic files: the number of words on
+	// the first line, then one word per line. Affix flags and morphological
+	// fields are dropped on import.
+	HunspellDic
+)
+
+// personalMu serializes the changes to the personal word lists.
+var personalMu sync.Mutex
+
+// PersonalDir returns the directory where Enchant keeps the personal word
+// lists. It's $ENCHANT_CONFIG_DIR if set, or the enchant directory inside the
+// user's configuration directory otherwise.
+func PersonalDir() string {
+	if dir := os.Getenv("ENCHANT_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+
+	return filepath.Join(C.GoString(C.g_get_user_config_dir()), "enchant")
+}
+
+// PersonalLanguages returns the codes of the languages that have a personal
+// word list, sorted.
+func PersonalLanguages() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(PersonalDir(), "*.dic"))
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, len(matches))
+	for i, match := range matches {
+		codes[i] = strings.TrimSuffix(filepath.Base(match), ".dic")
+	}
+
+	sort.Strings(codes)
+	return codes, nil
+}
+
+// PersonalDictionary is the personal word list of a language, which is where
+// Checker.AddWordToPersonal saves words.
+//
+// Checkers see the changes at their next check, since Enchant reloads the file
+// when it changes. Views only re-check their text when their Checker signals a
+// change, so words added through the dictionary of a Checker are signalled on
+// that Checker like gspell does. Other Checkers aren't signalled, and neither
+// are removed words, since gspell has no signal for them.
+type PersonalDictionary struct {
+	code string
+	// checker is the Checker that added words are signalled on, or nil.
+	checker *Checker
+}
+
+// NewPersonalDictionary returns the personal dictionary of the given language
+// code, for example en_US. The word list file doesn't have to exist yet.
+func NewPersonalDictionary(languageCode string) *PersonalDictionary {
+	return &PersonalDictionary{code: languageCode}
+}
+
+// PersonalDictionary returns the personal dictionary of the checker's current
+// language, or nil if no dictionaries are available. Words added through it are
+// signalled on the checker, so its methods must be called from the main thread.
+func (c *Checker) PersonalDictionary() *PersonalDictionary {
+	lang := c.GetLanguage()
+	if lang == nil {
+		return nil
+	}
+
+	return &PersonalDictionary{code: lang.GetCode(), checker: c}
+}
+
+// LanguageCode returns the language code of the dictionary.
+func (d *PersonalDictionary) LanguageCode() string {
+	return d.code
+}
+
+// Path returns the path of the word list file.
+func (d *PersonalDictionary) Path() string {
+	return filepath.Join(PersonalDir(), d.code+".dic")
+}
+
+// Words returns the words of the dictionary in the order they were added. It
+// returns no error if the word list file doesn't exist.
+func (d *PersonalDictionary) Words() ([]string, error) {
+	lines, err := d.readLines()
+	if err != nil {
+		return nil, err
+	}
+
+	return personalWords(lines), nil
+}
+
+// Add adds the words that are not in the dictionary yet. If it's the dictionary
+// of a Checker, the Checker signals word-added-to-personal once with the last
+// added word, so that its views re-check their text once.
+func (d *PersonalDictionary) Add(words ...string) error {
+	var added []string
+
+	err := d.update(words, func(lines []string) []string {
+		existing := stringSet(personalWords(lines))
+
+		for _, word := range words {
+			if _, ok := existing[word]; !ok {
+				existing[word] = struct{}{}
+				lines = append(lines, word)
+				added = append(added, word)
+			}
+		}
+
+		return lines
+	})
+
+	if err != nil || len(added) == 0 || d.checker == nil {
+		return err
+	}
+
+	cword := C.CString(added[len(added)-1])
+	defer C.free(unsafe.Pointer(cword))
+
+	C.gspell_go_word_added_to_personal(d.checker.native(), (*C.gchar)(unsafe.Pointer(cword)))
+	return nil
+}
+
+// Remove removes the words from the dictionary. Words that are not in it are
+// ignored. Contrary to enchant_dict_remove, the words are not excluded, so a
+// word that the language's dictionary knows stays correctly spelled.
+func (d *PersonalDictionary) Remove(words ...string) error {
+	return d.update(words, func(lines []string) []string {
+		removed := stringSet(words)
+		kept := lines[:0]
+
+		for _, line := range lines {
+			if _, ok := removed[line]; !ok {
+				kept = append(kept, line)
+			}
+		}
+
+		return kept
+	})
+}
+
+// Import reads a word list in the given format and adds its words.
+func (d *PersonalDictionary) Import(r io.Reader, format WordListFormat) error {
+	words, err := ReadWordList(r, format)
+	if err != nil {
+		return err
+	}
+
+	return d.Add(words...)
+}
+
+// Export writes the words of the dictionary in the given format.
+func (d *PersonalDictionary) Export(w io.Writer, format WordListFormat) error {
+	words, err := d.Words()
+	if err != nil {
+		return err
+	}
+
+	return WriteWordList(w, words, format)
+}
+
+// readLines reads the lines of the word list file without their delimiters.
+func (d *PersonalDictionary) readLines() ([]string, error) {
+	b, err := ioutil.ReadFile(d.Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read personal dictionary: %w", err)
+	}
+
+	// Enchant skips the byte order mark.
+	b = bytes.TrimPrefix(b, []byte("\ufeff"))
+
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines, nil
+}
+
+// update validates the words, then rewrites the word list file with the lines
+// returned by fn.
+func (d *PersonalDictionary) update(words []string, fn func([]string) []string) error {
+	for _, word := range words {
+		if err := validateWord(word); err != nil {
+			return err
+		}
+	}
+
+	return d.rewrite(fn)
+}
+
+// rewrite replaces the lines of the word list file with the ones returned by fn.
+func (d *PersonalDictionary) rewrite(fn func([]string) []string) error {
+	personalMu.Lock()
+	defer personalMu.Unlock()
+
+	lines, err := d.readLines()
+	if err != nil {
+		return err
+	}
+
+	if err := writePersonal(d.Path(), fn(lines)); err != nil {
+		return fmt.Errorf("failed to write personal dictionary: %w", err)
+	}
+
+	return nil
+}
+
+// writePersonal replaces the file at path with the given lines. If path is a
+// symbolic link, the file it points to is replaced instead, keeping the link.
+// The mode of the file is kept, and new files are only readable by the user.
+func writePersonal(path string, lines []string) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	var old os.FileInfo
+	if s, err := os.Stat(path); err == nil {
+		old = s
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if old != nil {
+		if err := f.Chmod(old.Mode().Perm()); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		w.WriteString(line)
+		w.WriteByte('\n')
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// Enchant only reloads the file when its modification time changes, which
+	// it compares in seconds.
+	if old != nil {
+		now := time.Now()
+		if now.Unix() <= old.ModTime().Unix() {
+			now = time.Unix(old.ModTime().Unix()+1, 0)
+		}
+		if err := os.Chtimes(f.Name(), now, now); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// personalWords returns the words in the lines of a word list file, skipping
+// comments and empty lines like Enchant does.
+func personalWords(lines []string) []string {
+	words := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line != "" && line[0] != '#' {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+func validateWord(word string) error {
+	switch {
+	case word == "":
+		return errors.New("word is empty")
+	case word[0] == '#':
+		return fmt.Errorf("word %q starts with #", word)
+	case strings.ContainsAny(word, "\r\n\x00"):
+		return fmt.Errorf("word %q contains a line break or nul byte", word)
+	case !utf8.ValidString(word):
+		return fmt.Errorf("word %q is not valid UTF-8", word)
+	}
+	return nil
+}
+
+func stringSet(strs []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(strs))
+	for _, str := range strs {
+		set[str] = struct{}{}
+	}
+	return set
+}
+
+// ReadWordList reads the words of a word list in the given format.
+func ReadWordList(r io.Reader, format WordListFormat) ([]string, error) {
+	var words []string
+	var scanner = bufio.NewScanner(r)
+
+	for n := 0; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if n == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		switch format {
+		case PlainText:
+			line = strings.TrimSpace(line)
+			if line == "" || line[0] == '#' {
+				continue
+			}
+
+		case HunspellDic:
+			// The first line is the approximate number of words.
+			if n == 0 {
+				if _, err := strconv.Atoi(strings.TrimSpace(line)); err == nil {
+					continue
+				}
+			}
+
+			line = hunspellWord(line)
+			if line == "" {
+				continue
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown word list format %d", format)
+		}
+
+		words = append(words, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+
+	return words, nil
+}
+
+// hunspellWord returns the word of a line of a Hunspell .dic file without its
+// flags and morphological fields. Slashes and backslashes in the word are
+// escaped with a backslash.
+func hunspellWord(line string) string {
+	if i := strings.IndexByte(line, '\t'); i != -1 {
+		line = line[:i]
+	}
+
+	var word strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && (line[i+1] == '/' || line[i+1] == '\\'):
+			word.WriteByte(line[i+1])
+			i++
+		case line[i] == '/':
+			return strings.TrimSpace(word.String())
+		default:
+			word.WriteByte(line[i])
+		}
+	}
+
+	return strings.TrimSpace(word.String())
+}
+
+var hunspellEscaper = strings.NewReplacer(`\`, `\\`, "/", `\/`)
+
+// WriteWordList writes the words as a word list in the given format.
+func WriteWordList(w io.Writer, words []string, format WordListFormat) error {
+	bw := bufio.NewWriter(w)
+
+	switch format {
+	case PlainText:
+		for _, word := range words {
+			bw.WriteString(word)
+			bw.WriteByte('\n')
+		}
+
+	case HunspellDic:
+		bw.WriteString(strconv.Itoa(len(words)))
+		bw.WriteByte('\n')
+
+		for _, word := range words {
+			bw.WriteString(hunspellEscaper.Replace(word))
+			bw.WriteByte('\n')
+		}
+
+	default:
+		return fmt.Errorf("unknown word list format %d", format)
+	}
+
+	return bw.Flush()
+}
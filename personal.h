@@ -0,0 +1,9 @@
+#include <gspell/gspell.h>
+
+// gspell_go_word_added_to_personal signals word-added-to-personal on the
+// checker, like gspell_checker_add_word_to_personal does once the word is
+// saved.
+static void gspell_go_word_added_to_personal(GspellChecker *checker,
+		const gchar *word) {
+	g_signal_emit_by_name(checker, "word-added-to-personal", word);
+}
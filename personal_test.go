@@ -0,0 +1,239 @@
+package gspell
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHunspellWord(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"", ""},
+		{"word", "word"},
+		{"word/ABC", "word"},
+		{"word/ABC\tpo:noun", "word"},
+		{"word\tpo:noun", "word"},
+		{" spaced /A", "spaced"},
+		{`and\/or/X`, "and/or"},
+		{`back\\slash`, `back\slash`},
+		{`back\\/X`, `back\`},
+		{`other\escape`, `other\escape`},
+		{`trailing\`, `trailing\`},
+		{"/A", ""},
+	}
+
+	for _, test := range tests {
+		if got := hunspellWord(test.line); got != test.want {
+			t.Errorf("hunspellWord(%q) = %q, want %q", test.line, got, test.want)
+		}
+	}
+}
+
+func TestReadWordList(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		format WordListFormat
+		want   []string
+	}{{
+		name:   "plain text",
+		text:   "\ufeffone\r\n  two  \n\n# comment\nthree",
+		format: PlainText,
+		want:   []string{"one", "two", "three"},
+	}, {
+		name:   "empty plain text",
+		format: PlainText,
+	}, {
+		name:   "hunspell",
+		text:   "\ufeff3\nword/AB\nand\\/or\nback\\\\slash\tpo:noun\n",
+		format: HunspellDic,
+		want:   []string{"word", "and/or", `back\slash`},
+	}, {
+		name:   "hunspell without count",
+		text:   "first\n/X\nsecond/Y",
+		format: HunspellDic,
+		want:   []string{"first", "second"},
+	}, {
+		name:   "hunspell count only on the first line",
+		text:   "1\n42",
+		format: HunspellDic,
+		want:   []string{"42"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ReadWordList(strings.NewReader(test.text), test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if _, err := ReadWordList(strings.NewReader("word"), WordListFormat(42)); err == nil {
+		t.Error("an unknown format was read")
+	}
+}
+
+func TestWriteWordList(t *testing.T) {
+	words := []string{"word", "and/or", `back\slash`, `\/`, "Größe"}
+
+	tests := []struct {
+		format WordListFormat
+		want   string
+	}{{
+		format: PlainText,
+		want:   "word\nand/or\nback\\slash\n\\/\nGröße\n",
+	}, {
+		format: HunspellDic,
+		want:   "5\nword\nand\\/or\nback\\\\slash\n\\\\\\/\nGröße\n",
+	}}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := WriteWordList(&buf, words, test.format); err != nil {
+			t.Fatal(err)
+		}
+
+		if buf.String() != test.want {
+			t.Errorf("format %d: got %q, want %q", test.format, buf.String(), test.want)
+		}
+
+		// Reading the list back gives the same words.
+		got, err := ReadWordList(&buf, test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, words) {
+			t.Errorf("format %d: read back %q, want %q", test.format, got, words)
+		}
+	}
+
+	if err := WriteWordList(ioutil.Discard, words, WordListFormat(42)); err == nil {
+		t.Error("an unknown format was written")
+	}
+}
+
+func TestWritePersonal(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "sub", "en_US.dic")
+	if err := writePersonal(path, []string{"one"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := s.Mode().Perm(); mode != 0o600 {
+		t.Errorf("new file has mode %v, want 0600", mode)
+	}
+
+	// The mode of an existing file is kept.
+	if err := os.Chmod(path, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePersonal(path, []string{"one", "two"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if mode := s.Mode().Perm(); mode != 0o640 {
+		t.Errorf("rewritten file has mode %v, want 0640", mode)
+	}
+
+	// A symbolic link is kept, and the file it points to is written.
+	link := filepath.Join(dir, "link.dic")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skip("can't create symbolic links:", err)
+	}
+	if err := writePersonal(link, []string{"three"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := os.Lstat(link); err != nil {
+		t.Fatal(err)
+	} else if s.Mode()&os.ModeSymlink == 0 {
+		t.Error("the symbolic link was replaced")
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "three\n" {
+		t.Errorf("the target of the link contains %q", b)
+	}
+
+	// No temporary files are left.
+	entries, err := ioutil.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d files next to the dictionary, want 1", len(entries))
+	}
+}
+
+func TestPersonalDictionarySignals(t *testing.T) {
+	checker := testChecker(t)
+
+	old, ok := os.LookupEnv("ENCHANT_CONFIG_DIR")
+	os.Setenv("ENCHANT_CONFIG_DIR", t.TempDir())
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv("ENCHANT_CONFIG_DIR", old)
+		} else {
+			os.Unsetenv("ENCHANT_CONFIG_DIR")
+		}
+	})
+
+	var added []string
+	checker.Connect("word-added-to-personal", func(_ *Checker, word string) {
+		added = append(added, word)
+	})
+
+	dict := checker.PersonalDictionary()
+
+	// The views re-check once, however many words are added.
+	if err := dict.Add("frobnicate", "gspellgo"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(added, []string{"gspellgo"}) {
+		t.Fatalf("word-added-to-personal was emitted for %q", added)
+	}
+
+	// Words that are already there, removed words and the dictionaries of no
+	// Checker aren't signalled.
+	if err := dict.Add("frobnicate"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dict.Remove("frobnicate"); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPersonalDictionary(dict.LanguageCode()).Add("quuxify"); err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 {
+		t.Errorf("word-added-to-personal was emitted for %q", added)
+	}
+
+	words, err := dict.Words()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, []string{"gspellgo", "quuxify"}) {
+		t.Errorf("got words %q", words)
+	}
+}
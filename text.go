@@ -0,0 +1,120 @@
+package gspell
+
+// #include <stdlib.h>
+// #include "text.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/diamondburned/gspell/gextras"
+)
+
+// maxSpans is the length of the array type used to slice the spans from C.
+const maxSpans = (1<<31 - 1) / unsafe.Sizeof(C.GspellGoSpan{})
+
+// Misspelling is a misspelled word found by CheckText.
+type Misspelling struct {
+	Word string
+	// Start and End are the byte offsets of the word in the text.
+	Start int
+	End   int
+	// RuneStart and RuneEnd are the rune offsets of the word in the text.
+	RuneStart int
+	RuneEnd   int
+
+	suggestions *lazySuggestions
+}
+
+// lazySuggestions holds the suggestions of a word, which are only looked up
+// when needed.
+type lazySuggestions struct {
+	once    sync.Once
+	suggest func(word string) []string
+	word    string
+	list    []string
+}
+
+// Suggestions returns the suggestions for the word. They're looked up on the
+// first call only.
+func (m Misspelling) Suggestions() []string {
+	if m.suggestions == nil {
+		return nil
+	}
+
+	s := m.suggestions
+	s.once.Do(func() {
+		s.list = s.suggest(s.word)
+		s.suggest = nil
+	})
+
+	return s.list
+}
+
+// CheckText checks the spelling of a plain text, splitting it into words the
+// same way the inline checker of TextView and Entry does. The misspelled words
+// are returned in order. The text must be valid UTF-8 without nul bytes.
+func (c *Checker) CheckText(text string) ([]Misspelling, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	if !utf8.ValidString(text) {
+		return nil, errors.New("text is not valid UTF-8")
+	}
+	if strings.IndexByte(text, 0) != -1 {
+		return nil, errors.New("text contains a nul byte")
+	}
+
+	ctext := C.CString(text)
+	defer C.free(unsafe.Pointer(ctext))
+
+	var spans *C.GspellGoSpan
+	var nspans C.gsize
+	var gerr *C.GError
+
+	ok := C.gspell_go_check_text(
+		c.native(), ctext, C.gsize(len(text)), &spans, &nspans, &gerr,
+	)
+	if !gextras.Gobool(int(ok)) {
+		return nil, fmt.Errorf("failed to check text: %w", gextras.TakeError(unsafe.Pointer(gerr)))
+	}
+
+	defer C.g_free(C.gpointer(unsafe.Pointer(spans)))
+
+	if nspans == 0 {
+		return nil, nil
+	}
+
+	cspans := (*[maxSpans]C.GspellGoSpan)(unsafe.Pointer(spans))[:nspans:nspans]
+
+	misspellings := make([]Misspelling, len(cspans))
+
+	for i, span := range cspans {
+		word := text[span.byte_start:span.byte_end]
+
+		misspellings[i] = Misspelling{
+			Word:      word,
+			Start:     int(span.byte_start),
+			End:       int(span.byte_end),
+			RuneStart: int(span.rune_start),
+			RuneEnd:   int(span.rune_end),
+			suggestions: &lazySuggestions{
+				suggest: c.suggestions,
+				word:    word,
+			},
+		}
+	}
+
+	return misspellings, nil
+}
+
+// suggestions returns the suggestions of a single word.
+func (c *Checker) suggestions(word string) []string {
+	return c.GetSuggestionsBatch([]string{word})[0]
+}
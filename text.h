@@ -0,0 +1,92 @@
+#include <pango/pango.h>
+#include <gspell/gspell.h>
+
+// GspellGoSpan is the span of a word in a text.
+typedef struct {
+	gsize byte_start;
+	gsize byte_end;
+	glong rune_start;
+	glong rune_end;
+} GspellGoSpan;
+
+static gboolean gspell_go_is_apostrophe_or_dash(gunichar ch) {
+	return ch == '-' ||
+		ch == '\'' ||
+		ch == 0x2019 || // RIGHT SINGLE QUOTATION MARK
+		ch == 0x02BC;   // MODIFIER LETTER APOSTROPHE
+}
+
+// gspell_go_improve_word_boundaries joins words separated by a single
+// apostrophe or dash, such as "doesn't" or "spell-checking", like gspell does
+// for its inline checker.
+static void gspell_go_improve_word_boundaries(const gchar *text, gsize length,
+		PangoLogAttr *attrs, gint n_attrs) {
+	const gchar *p = text;
+
+	for (gint i = 0; i + 1 < n_attrs && p < text + length; i++) {
+		gunichar ch = g_utf8_get_char(p);
+
+		if (gspell_go_is_apostrophe_or_dash(ch) &&
+				attrs[i].is_word_end && attrs[i + 1].is_word_start) {
+			attrs[i].is_word_end = FALSE;
+			attrs[i + 1].is_word_start = FALSE;
+		}
+
+		p = g_utf8_next_char(p);
+	}
+}
+
+// gspell_go_check_text splits text into words like gspell's inline checker
+// and checks them. The spans of the misspelled words are returned in spans,
+// which must be freed with g_free.
+static gboolean gspell_go_check_text(GspellChecker *checker, const gchar *text,
+		gsize length, GspellGoSpan **spans, gsize *n_spans, GError **error) {
+	glong n_chars = g_utf8_strlen(text, length);
+	gint n_attrs = n_chars + 1;
+
+	PangoLogAttr *attrs = g_new0(PangoLogAttr, n_attrs);
+	pango_get_log_attrs(text, length, -1, NULL, attrs, n_attrs);
+	gspell_go_improve_word_boundaries(text, length, attrs, n_attrs);
+
+	GArray *found = g_array_new(FALSE, FALSE, sizeof(GspellGoSpan));
+	GspellGoSpan span = {0};
+	gboolean in_word = FALSE;
+	const gchar *p = text;
+
+	for (gint i = 0; i < n_attrs; i++) {
+		if (in_word && attrs[i].is_word_end) {
+			span.byte_end = p - text;
+			span.rune_end = i;
+			in_word = FALSE;
+
+			gboolean correct = gspell_checker_check_word(checker,
+					text + span.byte_start, span.byte_end - span.byte_start, error);
+
+			if (*error != NULL) {
+				g_array_free(found, TRUE);
+				g_free(attrs);
+				return FALSE;
+			}
+
+			if (!correct) {
+				g_array_append_val(found, span);
+			}
+		}
+
+		if (attrs[i].is_word_start) {
+			span.byte_start = p - text;
+			span.rune_start = i;
+			in_word = TRUE;
+		}
+
+		if (i < n_chars) {
+			p = g_utf8_next_char(p);
+		}
+	}
+
+	g_free(attrs);
+
+	*n_spans = found->len;
+	*spans = (GspellGoSpan *)g_array_free(found, FALSE);
+	return TRUE;
+}
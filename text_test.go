@@ -0,0 +1,130 @@
+package gspell
+
+import "testing"
+
+func TestCheckText(t *testing.T) {
+	checker := testChecker(t)
+
+	type span struct {
+		Word               string
+		Start, End         int
+		RuneStart, RuneEnd int
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []span
+	}{{
+		name: "correct",
+		text: "Hello, world.",
+	}, {
+		name: "misspelled",
+		text: "Hello wrold",
+		want: []span{{"wrold", 6, 11, 6, 11}},
+	}, {
+		name: "apostrophe inside word",
+		text: "It doesn't matter, the wrold's end.",
+		want: []span{{"wrold's", 23, 30, 23, 30}},
+	}, {
+		name: "typographic apostrophe inside word",
+		text: "It doesn’t wrold’s",
+		want: []span{{"wrold’s", 13, 22, 11, 18}},
+	}, {
+		name: "dash inside word",
+		text: "A well-known helo-wrold program.",
+		want: []span{{"helo-wrold", 13, 23, 13, 23}},
+	}, {
+		name: "digits",
+		text: "Call 12345 or 3.14 now",
+	}, {
+		name: "non-ASCII offsets",
+		text: "«wrold» — wrold",
+		want: []span{
+			{"wrold", 2, 7, 1, 6},
+			{"wrold", 14, 19, 10, 15},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			misspellings, err := checker.CheckText(test.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(misspellings) != len(test.want) {
+				t.Fatalf("got %d misspellings, want %d: %+v", len(misspellings), len(test.want), misspellings)
+			}
+
+			for i, m := range misspellings {
+				got := span{m.Word, m.Start, m.End, m.RuneStart, m.RuneEnd}
+				if got != test.want[i] {
+					t.Errorf("misspelling %d: got %+v, want %+v", i, got, test.want[i])
+				}
+				if test.text[m.Start:m.End] != m.Word {
+					t.Errorf("misspelling %d: %q is not at its byte offsets", i, m.Word)
+				}
+				if string([]rune(test.text)[m.RuneStart:m.RuneEnd]) != m.Word {
+					t.Errorf("misspelling %d: %q is not at its rune offsets", i, m.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTextInvalid(t *testing.T) {
+	checker := testChecker(t)
+
+	for _, text := range []string{"wrold\xff", "wrold\x00"} {
+		if _, err := checker.CheckText(text); err == nil {
+			t.Errorf("CheckText(%q) didn't fail", text)
+		}
+	}
+
+	if misspellings, err := checker.CheckText(""); misspellings != nil || err != nil {
+		t.Errorf("CheckText(\"\") = %v, %v; want nil, nil", misspellings, err)
+	}
+}
+
+func TestMisspellingSuggestions(t *testing.T) {
+	checker := testChecker(t)
+
+	misspellings, err := checker.CheckText("wrold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(misspellings) != 1 {
+		t.Fatalf("got %d misspellings, want 1", len(misspellings))
+	}
+
+	m := misspellings[0]
+
+	// The suggestions are only looked up when asked for.
+	if m.suggestions.suggest == nil || m.suggestions.list != nil {
+		t.Fatal("the suggestions were looked up by CheckText")
+	}
+
+	suggestions := m.Suggestions()
+	if m.suggestions.suggest != nil {
+		t.Error("the lookup function wasn't released")
+	}
+
+	var found bool
+	for _, s := range suggestions {
+		found = found || s == "world"
+	}
+	if !found {
+		t.Errorf("the suggestions %q lack world", suggestions)
+	}
+
+	// Copies of the misspelling share the looked up suggestions.
+	copied := m
+	if again := copied.Suggestions(); len(again) != len(suggestions) || &again[0] != &suggestions[0] {
+		t.Error("the suggestions were looked up again")
+	}
+
+	if (Misspelling{}).Suggestions() != nil {
+		t.Error("the zero Misspelling has suggestions")
+	}
+}
@@ -0,0 +1,267 @@
+package gspell
+
+// #include "wordlist.h"
+import "C"
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+// WordList is a list of words, such as product names and jargon, that are
+// added to the session of the Checkers it's attached to. The words are added
+// again whenever the session is cleared or the language changes.
+//
+// Each word is either language-independent or belongs to a language code. The
+// words of a code apply to the languages with that code and, if the code has no
+// territory like "en", to all its territories like "en_US".
+type WordList struct {
+	mu       sync.Mutex
+	words    map[string][]string
+	checkers map[*C.GspellChecker]*attachedChecker
+}
+
+// attachedChecker is a Checker with the number of times it's attached and the
+// handlers connected to it, which are shared by all attachments.
+type attachedChecker struct {
+	checker *Checker
+	count   int
+	handles []glib.SignalHandle
+}
+
+// NewWordList creates an empty word list.
+func NewWordList() *WordList {
+	return &WordList{
+		words:    map[string][]string{},
+		checkers: map[*C.GspellChecker]*attachedChecker{},
+	}
+}
+
+// Add adds the words for the given language code, or for all languages if the
+// code is empty. Attached Checkers get the words right away. Empty words are
+// ignored.
+func (l *WordList) Add(languageCode string, words ...string) {
+	nonEmpty := words[:0:0]
+	for _, word := range words {
+		if word != "" {
+			nonEmpty = append(nonEmpty, word)
+		}
+	}
+	words = nonEmpty
+
+	l.mu.Lock()
+	l.words[languageCode] = append(l.words[languageCode], words...)
+	checkers := make([]*Checker, 0, len(l.checkers))
+	for _, attached := range l.checkers {
+		checkers = append(checkers, attached.checker)
+	}
+	l.mu.Unlock()
+
+	for _, checker := range checkers {
+		lang := checker.GetLanguage()
+		if lang != nil && languageMatches(languageCode, lang.GetCode()) {
+			addWordsToSession(checker, words)
+		}
+	}
+}
+
+// Load reads a word list in the given format and adds its words like Add.
+func (l *WordList) Load(r io.Reader, format WordListFormat, languageCode string) error {
+	words, err := ReadWordList(r, format)
+	if err != nil {
+		return err
+	}
+
+	l.Add(languageCode, words...)
+	return nil
+}
+
+// LoadFile loads the word list file at path. Files ending with .dic are read
+// as HunspellDic, and others as PlainText.
+func (l *WordList) LoadFile(path, languageCode string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer f.Close()
+
+	return l.Load(f, wordListFormat(path), languageCode)
+}
+
+// LoadFS loads the word list file with the given name from fsys, which is
+// usually an embed.FS, like LoadFile.
+func (l *WordList) LoadFS(fsys fs.FS, name, languageCode string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer f.Close()
+
+	return l.Load(f, wordListFormat(name), languageCode)
+}
+
+func wordListFormat(name string) WordListFormat {
+	if path.Ext(name) == ".dic" {
+		return HunspellDic
+	}
+	return PlainText
+}
+
+// Words returns the words that apply to the given language code, starting with
+// the language-independent ones.
+func (l *WordList) Words(languageCode string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	codes := make([]string, 0, len(l.words))
+	for code := range l.words {
+		if languageMatches(code, languageCode) {
+			codes = append(codes, code)
+		}
+	}
+
+	// Sorting puts the language-independent words first, and the words of a
+	// language before those of its territories.
+	sort.Strings(codes)
+
+	var words []string
+	for _, code := range codes {
+		words = append(words, l.words[code]...)
+	}
+
+	return words
+}
+
+// languageMatches returns true if the words of listCode apply to code.
+func languageMatches(listCode, code string) bool {
+	return listCode == "" || code == listCode || strings.HasPrefix(code, listCode+"_")
+}
+
+// Attach adds the words to the session of the checker, then adds them again
+// after session-cleared and when the language changes, until the returned
+// function is called. The words stay in the current session after detaching.
+//
+// The word list keeps a reference to the checker while it's attached. A
+// checker can be attached several times, and stays attached until all the
+// returned functions are called. Attaching it again doesn't connect more
+// handlers.
+func (l *WordList) Attach(checker *Checker) (detach func()) {
+	l.mu.Lock()
+	attached, ok := l.checkers[checker.native()]
+	if !ok {
+		apply := func() { l.apply(checker) }
+
+		attached = &attachedChecker{
+			checker: checker,
+			handles: []glib.SignalHandle{
+				checker.Connect("session-cleared", apply),
+				checker.Connect("notify::language", apply),
+			},
+		}
+		l.checkers[checker.native()] = attached
+	}
+	attached.count++
+	l.mu.Unlock()
+
+	// The words are already in the session of a checker attached before.
+	if !ok {
+		l.apply(checker)
+	}
+
+	var detached bool
+
+	return func() {
+		l.mu.Lock()
+		if detached {
+			l.mu.Unlock()
+			return
+		}
+		detached = true
+
+		attached.count--
+		last := attached.count == 0
+		if last {
+			delete(l.checkers, checker.native())
+		}
+		l.mu.Unlock()
+
+		if last {
+			for _, handle := range attached.handles {
+				attached.checker.HandlerDisconnect(handle)
+			}
+		}
+	}
+}
+
+// AttachTextBuffer attaches the word list to the Checker of the buffer like
+// Attach, following the buffer when its Checker is replaced.
+func (l *WordList) AttachTextBuffer(buffer *TextBuffer) (detach func()) {
+	return l.attachSpellChecker(buffer.Object, func() *C.GspellChecker {
+		return C.gspell_text_buffer_get_spell_checker(buffer.native())
+	})
+}
+
+// AttachEntryBuffer attaches the word list to the Checker of the buffer like
+// Attach, following the buffer when its Checker is replaced.
+func (l *WordList) AttachEntryBuffer(buffer *EntryBuffer) (detach func()) {
+	return l.attachSpellChecker(buffer.Object, func() *C.GspellChecker {
+		return C.gspell_entry_buffer_get_spell_checker(buffer.native())
+	})
+}
+
+// attachSpellChecker attaches the word list to the Checker returned by get, and
+// again whenever the spell-checker property of obj changes.
+func (l *WordList) attachSpellChecker(obj *glib.Object, get func() *C.GspellChecker) func() {
+	var detachChecker = func() {}
+
+	attach := func() {
+		detachChecker()
+		detachChecker = func() {}
+
+		if checker := get(); checker != nil {
+			detachChecker = l.Attach(WrapChecker(unsafe.Pointer(checker)))
+		}
+	}
+
+	attach()
+	handle := obj.Connect("notify::spell-checker", attach)
+
+	return func() {
+		obj.HandlerDisconnect(handle)
+		detachChecker()
+	}
+}
+
+// apply adds the words of the checker's language to its session.
+func (l *WordList) apply(checker *Checker) {
+	lang := checker.GetLanguage()
+	if lang == nil {
+		return
+	}
+
+	addWordsToSession(checker, l.Words(lang.GetCode()))
+}
+
+// addWordsToSession adds the words to the session of the checker with a single
+// cgo call.
+func addWordsToSession(checker *Checker, words []string) {
+	if len(words) == 0 {
+		return
+	}
+
+	buf, offsets := packWords(words)
+
+	C.gspell_go_add_words_to_session(
+		checker.native(),
+		(*C.gchar)(unsafe.Pointer(&buf[0])), &offsets[0], C.gsize(len(words)),
+	)
+}
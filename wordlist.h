@@ -0,0 +1,21 @@
+#include <gspell/gspell.h>
+
+// gspell_go_add_words_to_session adds the n words packed in buf to the session
+// of the checker like gspell_go_check_words. It then signals
+// word-added-to-session once with the last word, like
+// gspell_checker_add_word_to_session does, so that views re-check their text
+// once for the whole batch.
+static void gspell_go_add_words_to_session(GspellChecker *checker,
+		const gchar *buf, const gsize *offsets, gsize n) {
+	EnchantDict *dict = gspell_checker_get_enchant_dict(checker);
+	if (dict == NULL || n == 0)
+		return;
+
+	for (gsize i = 0; i < n; i++)
+		enchant_dict_add_to_session(dict, buf + offsets[i],
+				offsets[i + 1] - offsets[i]);
+
+	gchar *last = g_strndup(buf + offsets[n - 1], offsets[n] - offsets[n - 1]);
+	g_signal_emit_by_name(checker, "word-added-to-session", last);
+	g_free(last);
+}
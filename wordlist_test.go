@@ -0,0 +1,64 @@
+package gspell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWordListAttachTwice(t *testing.T) {
+	checker := testChecker(t)
+
+	var added []string
+	checker.Connect("word-added-to-session", func(_ *Checker, word string) {
+		added = append(added, word)
+	})
+
+	list := NewWordList()
+	list.Add("", "frobnicate")
+
+	// The words are added when the checker is first attached only.
+	detach1 := list.Attach(checker)
+	detach2 := list.Attach(checker)
+	if !reflect.DeepEqual(added, []string{"frobnicate"}) {
+		t.Fatalf("word-added-to-session was emitted for %q after attaching", added)
+	}
+
+	// The checker is attached once, so it gets the batch once, and the views
+	// re-check once for all its words.
+	added = nil
+	list.Add("", "gspellgo", "wordlister")
+	if !reflect.DeepEqual(added, []string{"wordlister"}) {
+		t.Fatalf("word-added-to-session was emitted for %q after adding", added)
+	}
+	if !checker.CheckWord("gspellgo", -1) {
+		t.Error("the first word of the batch wasn't added")
+	}
+
+	// The handlers are shared, so the words are added once after the session
+	// is cleared.
+	added = nil
+	checker.ClearSession()
+	if len(added) != 1 {
+		t.Errorf("word-added-to-session was emitted for %q after clearing", added)
+	}
+
+	// The checker stays attached until both are detached.
+	detach1()
+	detach1()
+	list.Add("", "quuxify")
+	if !checker.CheckWord("quuxify", -1) {
+		t.Error("the word wasn't added after detaching once")
+	}
+
+	detach2()
+	list.Add("", "zorblax")
+	if checker.CheckWord("zorblax", -1) {
+		t.Error("the word was added after detaching twice")
+	}
+
+	added = nil
+	checker.ClearSession()
+	if len(added) != 0 {
+		t.Errorf("word-added-to-session was emitted for %q after detaching", added)
+	}
+}